@@ -0,0 +1,189 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+)
+
+// TypedAccount is an account decoded according to its type, as returned by Client.GetTypedAccount
+// and Account.Typed. It is implemented by *BasicAccount, *VestingAccount and *HTLCAccount, so the
+// fields of a specific type are available by a type switch:
+//
+//   switch acc := account.Typed().(type) {
+//   case *nimiqrpc.VestingAccount:
+//       fmt.Println(acc.OwnerAddress)
+//   case *nimiqrpc.HTLCAccount:
+//       fmt.Println(acc.RecipientAddress)
+//   }
+type TypedAccount interface {
+	// AccountType returns the type of the account
+	AccountType() AccountType
+
+	// Flat returns the account as a flat Account
+	Flat() Account
+}
+
+// BasicAccount is an account that is controlled by a single key pair.
+// Its fields are shared by all account types.
+type BasicAccount struct {
+	ID      string `json:"id"`      // hex-encoded address bytes
+	Address string `json:"address"` // user friendly address (NQ-address)
+	Balance Luna   `json:"balance"` // balance of the account in Luna
+}
+
+// AccountType returns AccountTypeBasic
+func (acc *BasicAccount) AccountType() AccountType {
+	return AccountTypeBasic
+}
+
+// Flat returns the account as a flat Account
+func (acc *BasicAccount) Flat() Account {
+	return Account{
+		ID:      acc.ID,
+		Address: acc.Address,
+		Balance: acc.Balance,
+		Type:    AccountTypeBasic,
+	}
+}
+
+// ParsedAddress returns the address of the account, parsed from its user friendly address or its ID
+func (acc *BasicAccount) ParsedAddress() (Address, error) {
+	return parseEitherAddress(acc.Address, acc.ID)
+}
+
+// VestingAccount is a contract that releases its funds to its owner in steps
+type VestingAccount struct {
+	BasicAccount
+	Owner              string `json:"owner"`              // hex-encoded address of contract owner
+	OwnerAddress       string `json:"ownerAddress"`       // user friendly address of contract owner
+	VestingStart       int    `json:"vestingStart"`       // the block that the vesting contracted commenced
+	VestingStepBlocks  int    `json:"vestingStepBlocks"`  // no. of blocks after which some part of the vested funds is released
+	VestingStepAmount  Luna   `json:"vestingStepAmount"`  // amount in Luna released every VestingStepBlocks blocks
+	VestingTotalAmount Luna   `json:"vestingTotalAmount"` // total amount in Luna that was provided at the contract creation
+}
+
+// AccountType returns AccountTypeVesting
+func (acc *VestingAccount) AccountType() AccountType {
+	return AccountTypeVesting
+}
+
+// Flat returns the account as a flat Account
+func (acc *VestingAccount) Flat() Account {
+	flat := acc.BasicAccount.Flat()
+	flat.Type = AccountTypeVesting
+	flat.Owner = acc.Owner
+	flat.OwnerAddress = acc.OwnerAddress
+	flat.VestingStart = acc.VestingStart
+	flat.VestingStepBlocks = acc.VestingStepBlocks
+	flat.VestingStepAmount = int(acc.VestingStepAmount)
+	flat.VestingTotalAmount = int(acc.VestingTotalAmount)
+	return flat
+}
+
+// ParsedOwner returns the address of the owner of the contract
+func (acc *VestingAccount) ParsedOwner() (Address, error) {
+	return parseEitherAddress(acc.OwnerAddress, acc.Owner)
+}
+
+// HTLCAccount is a hashed time-locked contract
+type HTLCAccount struct {
+	BasicAccount
+	Sender           string `json:"sender"`           // hex-encoded address of HTLC sender
+	SenderAddress    string `json:"senderAddress"`    // user friendly address of HTLC sender
+	Recipient        string `json:"recipient"`        // hex-encoded address of HTLC recipient
+	RecipientAddress string `json:"recipientAddress"` // user friendly address of HTLC recipient
+	HashRoot         string `json:"hashRoot"`         // hex-encoded 32 byte hash root
+	HashCount        int    `json:"hashCount"`        // no. of hashes this HTLC is split into
+	Timeout          int    `json:"timeout"`          // block at which the HTLC times out
+	TotalAmount      Luna   `json:"totalAmount"`      // total amount in Luna provided at contract creation
+}
+
+// ParsedSender returns the address of the sender of the HTLC
+func (acc *HTLCAccount) ParsedSender() (Address, error) {
+	return parseEitherAddress(acc.SenderAddress, acc.Sender)
+}
+
+// ParsedRecipient returns the address of the recipient of the HTLC
+func (acc *HTLCAccount) ParsedRecipient() (Address, error) {
+	return parseEitherAddress(acc.RecipientAddress, acc.Recipient)
+}
+
+// AccountType returns AccountTypeHTLC
+func (acc *HTLCAccount) AccountType() AccountType {
+	return AccountTypeHTLC
+}
+
+// Flat returns the account as a flat Account
+func (acc *HTLCAccount) Flat() Account {
+	flat := acc.BasicAccount.Flat()
+	flat.Type = AccountTypeHTLC
+	flat.Sender = acc.Sender
+	flat.SenderAddress = acc.SenderAddress
+	flat.Recipient = acc.Recipient
+	flat.RecipientAddress = acc.RecipientAddress
+	flat.HashRoot = acc.HashRoot
+	flat.HashCount = acc.HashCount
+	flat.Timeout = acc.Timeout
+	flat.TotalAmount = int(acc.TotalAmount)
+	return flat
+}
+
+// Typed returns the account decoded according to its type. Accounts of unknown types are
+// returned as *BasicAccount.
+func (acc Account) Typed() TypedAccount {
+	basic := BasicAccount{
+		ID:      acc.ID,
+		Address: acc.Address,
+		Balance: acc.Balance,
+	}
+
+	switch acc.Type {
+	case AccountTypeVesting:
+		return &VestingAccount{
+			BasicAccount:       basic,
+			Owner:              acc.Owner,
+			OwnerAddress:       acc.OwnerAddress,
+			VestingStart:       acc.VestingStart,
+			VestingStepBlocks:  acc.VestingStepBlocks,
+			VestingStepAmount:  Luna(acc.VestingStepAmount),
+			VestingTotalAmount: Luna(acc.VestingTotalAmount),
+		}
+	case AccountTypeHTLC:
+		return &HTLCAccount{
+			BasicAccount:     basic,
+			Sender:           acc.Sender,
+			SenderAddress:    acc.SenderAddress,
+			Recipient:        acc.Recipient,
+			RecipientAddress: acc.RecipientAddress,
+			HashRoot:         acc.HashRoot,
+			HashCount:        acc.HashCount,
+			Timeout:          acc.Timeout,
+			TotalAmount:      Luna(acc.TotalAmount),
+		}
+	default:
+		return &basic
+	}
+}
+
+// DecodeAccount decodes the JSON representation of an account according to its type
+func DecodeAccount(data []byte) (TypedAccount, error) {
+	var acc Account
+	err := json.Unmarshal(data, &acc)
+	if err != nil {
+		return nil, err
+	}
+	return acc.Typed(), nil
+}
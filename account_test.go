@@ -0,0 +1,98 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecodeAccount(t *testing.T) {
+	account, err := DecodeAccount([]byte(`{"id":"000d1a2734414e5b6875828f9ca9b6c3d0ddeaf7","address":"NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP","balance":10000,"type":1,` +
+		`"owner":"7849ac3049680be1ef762efe0d36e01733c3464e","ownerAddress":"NQ92 F16S LC29 D05X 3UTN 5TX0 SDP0 2UUC 6HJE","vestingStart":100,"vestingStepBlocks":50,"vestingStepAmount":3000,"vestingTotalAmount":10000}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	vesting, ok := account.(*VestingAccount)
+	if !ok {
+		t.Fatalf("expected *VestingAccount, got %T", account)
+	}
+	if vesting.Balance != 10000 || vesting.Owner != "7849ac3049680be1ef762efe0d36e01733c3464e" || vesting.VestingStepAmount != 3000 {
+		t.Errorf("unexpected vesting account %+v", vesting)
+	}
+	if flat := vesting.Flat(); flat.Type != AccountTypeVesting || flat.VestingStepBlocks != 50 || flat.Address != vesting.Address {
+		t.Errorf("unexpected flat account %+v", flat)
+	}
+
+	account, err = DecodeAccount([]byte(`{"id":"000d1a2734414e5b6875828f9ca9b6c3d0ddeaf7","address":"NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP","balance":500,"type":0}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if basic, ok := account.(*BasicAccount); !ok || basic.Balance != 500 || account.AccountType() != AccountTypeBasic {
+		t.Errorf("expected basic account, got %+v", account)
+	}
+
+	if _, err := DecodeAccount([]byte(`{"type":"basic"}`)); err == nil {
+		t.Error("expected error for invalid account")
+	}
+}
+
+func TestAccountTyped(t *testing.T) {
+	for _, acc := range []Account{
+		{ID: "00", Address: "NQ07", Balance: 1, Type: AccountTypeBasic},
+		{ID: "00", Address: "NQ07", Balance: 1, Type: AccountTypeVesting, Owner: "01", OwnerAddress: "NQ01", VestingStart: 1, VestingStepBlocks: 2, VestingStepAmount: 3, VestingTotalAmount: 4},
+		{ID: "00", Address: "NQ07", Balance: 1, Type: AccountTypeHTLC, Sender: "01", SenderAddress: "NQ01", Recipient: "02", RecipientAddress: "NQ02", HashRoot: "ab", HashCount: 1, Timeout: 2, TotalAmount: 3},
+	} {
+		typed := acc.Typed()
+		if typed.AccountType() != acc.Type {
+			t.Errorf("expected account type %s, got %s", acc.Type, typed.AccountType())
+		}
+		if flat := typed.Flat(); flat != acc {
+			t.Errorf("expected %+v, got %+v", acc, flat)
+		}
+	}
+}
+
+func TestAccountTypeString(t *testing.T) {
+	if s := AccountTypeHTLC.String(); s != "htlc" {
+		t.Errorf("expected htlc, got %s", s)
+	}
+	if s := AccountType(7).String(); s != "unknown(7)" {
+		t.Errorf("expected unknown(7), got %s", s)
+	}
+	acc := Account{Type: AccountTypeVesting}
+	if s := fmt.Sprintf("%s", acc.Type); s != "vesting" {
+		t.Errorf("expected vesting, got %s", s)
+	}
+}
+
+func TestTypedAccountAddresses(t *testing.T) {
+	addr := MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	vesting := Account{ID: addr.Hex(), Type: AccountTypeVesting, Owner: addr.Hex()}.Typed().(*VestingAccount)
+	if a, err := vesting.ParsedAddress(); err != nil || a != addr {
+		t.Errorf("VestingAccount.ParsedAddress: got %s, %v", a, err)
+	}
+	if a, err := vesting.ParsedOwner(); err != nil || a != addr {
+		t.Errorf("VestingAccount.ParsedOwner: got %s, %v", a, err)
+	}
+
+	htlc := Account{Type: AccountTypeHTLC, SenderAddress: addr.String(), Recipient: addr.Hex()}.Typed().(*HTLCAccount)
+	if a, err := htlc.ParsedSender(); err != nil || a != addr {
+		t.Errorf("HTLCAccount.ParsedSender: got %s, %v", a, err)
+	}
+	if a, err := htlc.ParsedRecipient(); err != nil || a != addr {
+		t.Errorf("HTLCAccount.ParsedRecipient: got %s, %v", a, err)
+	}
+}
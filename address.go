@@ -0,0 +1,240 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidAddress is returned when an address cannot be parsed
+var ErrInvalidAddress = errors.New("invalid address")
+
+// base32Alphabet is the alphabet of the base32 encoding used by user friendly addresses
+const base32Alphabet = "0123456789ABCDEFGHJKLMNPQRSTUVXY"
+
+// Address is the address of an account. It is parsed from and formatted as a user friendly
+// address, like "NQ07 0000 0000 0000 0000 0000 0000 0000 0000", but also accepts the hex-encoded
+// address used by the ID fields of the node. An Address can be used as a field of a struct that
+// is (un)marshalled to JSON, since it implements encoding.TextMarshaler and encoding.TextUnmarshaler.
+type Address [20]byte
+
+// ParseAddress parses a user friendly or hex-encoded address. User friendly addresses are validated
+// by their checksum and may contain spaces and lower case characters.
+func ParseAddress(address string) (Address, error) {
+	normalized := strings.ToUpper(strings.Replace(address, " ", "", -1))
+
+	switch {
+	case len(normalized) == 40:
+		return parseHexAddress(normalized)
+	case len(normalized) == 36 && strings.HasPrefix(normalized, "NQ"):
+		return parseUserFriendlyAddress(normalized)
+	default:
+		return Address{}, fmt.Errorf("%w: %q has an unknown format", ErrInvalidAddress, address)
+	}
+}
+
+// MustParseAddress is like ParseAddress but panics if the address cannot be parsed.
+// It simplifies the initialization of variables with constant addresses.
+func MustParseAddress(address string) Address {
+	addr, err := ParseAddress(address)
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
+// parseHexAddress parses a hex-encoded address
+func parseHexAddress(address string) (Address, error) {
+	var addr Address
+	_, err := hex.Decode(addr[:], []byte(address))
+	if err != nil {
+		return Address{}, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	return addr, nil
+}
+
+// parseUserFriendlyAddress parses a user friendly address without spaces in upper case
+func parseUserFriendlyAddress(address string) (Address, error) {
+	if addressChecksum(address[4:]+address[:4]) != 1 {
+		return Address{}, fmt.Errorf("%w: %s has an invalid checksum", ErrInvalidAddress, address)
+	}
+
+	var addr Address
+	bits, value, n := 0, 0, 0
+	for _, c := range address[4:] {
+		index := strings.IndexRune(base32Alphabet, c)
+		if index < 0 {
+			return Address{}, fmt.Errorf("%w: %s contains invalid character %q", ErrInvalidAddress, address, c)
+		}
+		value = value<<5 | index
+		bits += 5
+		if bits >= 8 {
+			bits -= 8
+			addr[n] = byte(value >> uint(bits))
+			n++
+		}
+	}
+	return addr, nil
+}
+
+// addressChecksum returns the IBAN checksum (mod 97) of s, in which letters are replaced by two digits
+func addressChecksum(s string) int {
+	checksum := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			checksum = (checksum*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			checksum = (checksum*100 + int(c-'A') + 10) % 97
+		default:
+			return -1
+		}
+	}
+	return checksum
+}
+
+// String returns the user friendly address, in groups of four characters separated by spaces
+func (a Address) String() string {
+	address := a.Compact()
+
+	var spaced strings.Builder
+	for i := 0; i < len(address); i += 4 {
+		if i > 0 {
+			spaced.WriteByte(' ')
+		}
+		spaced.WriteString(address[i : i+4])
+	}
+	return spaced.String()
+}
+
+// Compact returns the user friendly address without spaces
+func (a Address) Compact() string {
+	var encoded strings.Builder
+	bits, value := 0, 0
+	for _, b := range a {
+		value = value<<8 | int(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			encoded.WriteByte(base32Alphabet[(value>>uint(bits))&31])
+		}
+	}
+	base32 := encoded.String()
+
+	check := 98 - addressChecksum(base32+"NQ00")
+	return fmt.Sprintf("NQ%02d%s", check, base32)
+}
+
+// Hex returns the hex-encoded address, as used by the ID fields of the node
+func (a Address) Hex() string {
+	return hex.EncodeToString(a[:])
+}
+
+// IsZero reports whether a is the zero address
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// MarshalText returns the user friendly address
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText parses a user friendly or hex-encoded address. An empty text results in the zero address.
+func (a *Address) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*a = Address{}
+		return nil
+	}
+
+	addr, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = addr
+	return nil
+}
+
+// ParsedAddress returns the address of the account, parsed from its user friendly address or its ID
+func (acc Account) ParsedAddress() (Address, error) {
+	return parseEitherAddress(acc.Address, acc.ID)
+}
+
+// ParsedOwner returns the address of the owner of a vesting contract
+func (acc Account) ParsedOwner() (Address, error) {
+	return parseEitherAddress(acc.OwnerAddress, acc.Owner)
+}
+
+// ParsedSender returns the address of the sender of an HTLC
+func (acc Account) ParsedSender() (Address, error) {
+	return parseEitherAddress(acc.SenderAddress, acc.Sender)
+}
+
+// ParsedRecipient returns the address of the recipient of an HTLC
+func (acc Account) ParsedRecipient() (Address, error) {
+	return parseEitherAddress(acc.RecipientAddress, acc.Recipient)
+}
+
+// ParsedAddress returns the address, parsed from its user friendly format or its ID
+func (a AddressObject) ParsedAddress() (Address, error) {
+	return parseEitherAddress(a.Address, a.ID)
+}
+
+// ParsedMiner returns the address of the miner of the block
+func (b Block) ParsedMiner() (Address, error) {
+	return parseEitherAddress(b.MinerAddress, b.Miner)
+}
+
+// ParsedMinerAddr returns the address of the miner of the block template
+func (b BlockTemplateBody) ParsedMinerAddr() (Address, error) {
+	return ParseAddress(b.MinerAddr)
+}
+
+// ParsedAddress returns the address of the wallet, parsed from its user friendly address or its ID
+func (w Wallet) ParsedAddress() (Address, error) {
+	return parseEitherAddress(w.Address, w.ID)
+}
+
+// ParsedFrom returns the address of the sending account of the transaction
+func (t Transaction) ParsedFrom() (Address, error) {
+	return parseEitherAddress(t.FromAddress, t.From)
+}
+
+// ParsedTo returns the address of the recipient account of the transaction
+func (t Transaction) ParsedTo() (Address, error) {
+	return parseEitherAddress(t.ToAddress, t.To)
+}
+
+// ParsedFrom returns the address of the sending account of the transaction
+func (t OutgoingTransaction) ParsedFrom() (Address, error) {
+	return ParseAddress(t.From)
+}
+
+// ParsedTo returns the address of the recipient account of the transaction
+func (t OutgoingTransaction) ParsedTo() (Address, error) {
+	return ParseAddress(t.To)
+}
+
+// parseEitherAddress parses the user friendly address, or the hex-encoded address if the user
+// friendly address is empty. Nodes omit one of both in some results.
+func parseEitherAddress(userFriendly, hexEncoded string) (Address, error) {
+	if userFriendly != "" {
+		return ParseAddress(userFriendly)
+	}
+	return ParseAddress(hexEncoded)
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParseAddress(t *testing.T) {
+	const (
+		userFriendly = "NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP"
+		hexAddress   = "000d1a2734414e5b6875828f9ca9b6c3d0ddeaf7"
+	)
+
+	for _, input := range []string{
+		userFriendly,
+		"nq49006hl9rl8575ns3mga7rradnqf8dtspp",
+		hexAddress,
+		"000D1A2734414E5B6875828F9CA9B6C3D0DDEAF7",
+	} {
+		addr, err := ParseAddress(input)
+		if err != nil {
+			t.Errorf("ParseAddress(%q): %v", input, err)
+			continue
+		}
+		if addr.String() != userFriendly || addr.Hex() != hexAddress {
+			t.Errorf("ParseAddress(%q): got %s, %s", input, addr, addr.Hex())
+		}
+	}
+
+	if addr := (Address{}); addr.String() != "NQ07 0000 0000 0000 0000 0000 0000 0000 0000" || !addr.IsZero() {
+		t.Errorf("unexpected zero address %s", addr)
+	}
+	if compact := MustParseAddress(userFriendly).Compact(); compact != "NQ49006HL9RL8575NS3MGA7RRADNQF8DTSPP" {
+		t.Errorf("unexpected compact address %s", compact)
+	}
+
+	for _, input := range []string{
+		"NQ48 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP", // wrong checksum
+		"NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPO", // invalid character
+		"NQ49 006H L9RL 8575",
+		"000d1a2734414e5b6875828f9ca9b6c3d0ddeaZZ",
+		"",
+	} {
+		if _, err := ParseAddress(input); !errors.Is(err, ErrInvalidAddress) {
+			t.Errorf("ParseAddress(%q): expected ErrInvalidAddress, got %v", input, err)
+		}
+	}
+}
+
+func TestAddressJSON(t *testing.T) {
+	var account struct {
+		ID      Address `json:"id"`
+		Address Address `json:"address"`
+		To      Address `json:"to"`
+	}
+	err := json.Unmarshal([]byte(`{"id":"000d1a2734414e5b6875828f9ca9b6c3d0ddeaf7","address":"NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP","to":""}`), &account)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account.ID != account.Address || !account.To.IsZero() {
+		t.Errorf("unexpected account %+v", account)
+	}
+
+	data, err := json.Marshal(account.Address)
+	if err != nil || string(data) != `"NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP"` {
+		t.Errorf("unexpected JSON %s, %v", data, err)
+	}
+
+	if err := json.Unmarshal([]byte(`"NQ00 0000"`), &account.To); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+}
+
+func TestParsedAddresses(t *testing.T) {
+	addr := MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+
+	// Results of the node contain either the user friendly or the hex-encoded address
+	if a, err := (Account{Address: addr.String()}).ParsedAddress(); err != nil || a != addr {
+		t.Errorf("Account.ParsedAddress: got %s, %v", a, err)
+	}
+	if a, err := (Wallet{ID: addr.Hex()}).ParsedAddress(); err != nil || a != addr {
+		t.Errorf("Wallet.ParsedAddress: got %s, %v", a, err)
+	}
+	trn := Transaction{From: addr.Hex(), ToAddress: addr.Compact()}
+	if a, err := trn.ParsedFrom(); err != nil || a != addr {
+		t.Errorf("Transaction.ParsedFrom: got %s, %v", a, err)
+	}
+	if a, err := trn.ParsedTo(); err != nil || a != addr {
+		t.Errorf("Transaction.ParsedTo: got %s, %v", a, err)
+	}
+	acc := Account{OwnerAddress: addr.String(), Sender: addr.Hex(), RecipientAddress: addr.String()}
+	for name, parse := range map[string]func() (Address, error){
+		"Account.ParsedOwner":               acc.ParsedOwner,
+		"Account.ParsedSender":              acc.ParsedSender,
+		"Account.ParsedRecipient":           acc.ParsedRecipient,
+		"AddressObject.ParsedAddress":       AddressObject{ID: addr.Hex()}.ParsedAddress,
+		"Block.ParsedMiner":                 Block{MinerAddress: addr.String()}.ParsedMiner,
+		"BlockTemplateBody.ParsedMinerAddr": BlockTemplateBody{MinerAddr: addr.Hex()}.ParsedMinerAddr,
+	} {
+		if a, err := parse(); err != nil || a != addr {
+			t.Errorf("%s: got %s, %v", name, a, err)
+		}
+	}
+
+	if _, err := (OutgoingTransaction{To: "NQ00"}).ParsedTo(); !errors.Is(err, ErrInvalidAddress) {
+		t.Errorf("expected ErrInvalidAddress, got %v", err)
+	}
+}
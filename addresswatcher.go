@@ -0,0 +1,333 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Payment is emitted by an AddressWatcher when a watched address receives a transaction
+type Payment struct {
+	Address       Address     // watched address that receives the transaction
+	Transaction   Transaction // the incoming transaction
+	Confirmations int         // number of blocks that confirm the transaction, 0 if it is in the mempool
+
+	// Reverted is set when the block that included the transaction was orphaned by a chain
+	// reorganisation. The payment is emitted again if the transaction is included in another block.
+	Reverted bool
+}
+
+// AddressWatcherConfig configures an AddressWatcher. Zero values are replaced by sensible defaults.
+type AddressWatcherConfig struct {
+	// Addresses contains the addresses to watch. Addresses can be added and removed later
+	// with Add and Remove.
+	Addresses []Address
+
+	// StartHeight is the height of the first block that is searched for payments. To resume after
+	// a restart, set it to the height after the last processed block. Defaults to the height after
+	// the current head of the node.
+	StartHeight int
+
+	// PollInterval is the interval at which the node is polled for new blocks and the mempool.
+	// Defaults to 5 seconds.
+	PollInterval time.Duration
+
+	// Confirmations is the number of confirmations a payment must reach. A payment is emitted again
+	// with the new number of confirmations for every block until it is reached. Defaults to 1, which
+	// emits a payment only once when it is mined. Payments are reverted by chain reorganisations
+	// that are up to 10 blocks, or Confirmations blocks if more, deep.
+	Confirmations int
+
+	// Mempool sets whether payments are also emitted as soon as they appear in the mempool,
+	// with 0 confirmations
+	Mempool bool
+
+	// OnError is called with every error that occurs while polling the node. The watcher keeps
+	// polling after an error.
+	OnError func(err error)
+}
+
+// AddressWatcher watches a set of addresses for incoming payments. It follows the main chain with a
+// ChainFollower, searches every new block for transactions to the watched addresses, and emits a
+// Payment for every block until the payment reached the configured number of confirmations. When a
+// block with payments is orphaned, the payments are emitted again with Reverted set. Optionally the
+// mempool is searched as well, to detect payments before they are mined.
+type AddressWatcher struct {
+	api        NimiqAPI
+	config     AddressWatcherConfig
+	reorgDepth int
+	chain      *ChainFollower
+	payments   chan Payment
+
+	mu        sync.Mutex
+	addresses map[Address]struct{} // watched addresses
+
+	confirming []confirmingPayment // mined payments that have not reached the confirmations
+	mined      map[string]int      // heights of the blocks that include the emitted payments within the reorg depth, by hash
+	mempool    map[string]struct{} // hashes of the payments that were emitted from the mempool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// confirmingPayment is a mined payment that has not reached the confirmations
+type confirmingPayment struct {
+	Payment
+	height int // height of the block that includes the transaction
+}
+
+// NewAddressWatcher returns a new AddressWatcher that polls api for payments to the addresses in config.
+// The node is polled in the background until Close is called.
+func NewAddressWatcher(api NimiqAPI, config AddressWatcherConfig) *AddressWatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.Confirmations <= 0 {
+		config.Confirmations = 1
+	}
+	// Payments must be reverted as long as they are confirmed
+	reorgDepth := 10
+	if config.Confirmations > reorgDepth {
+		reorgDepth = config.Confirmations
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	aw := &AddressWatcher{
+		api:        api,
+		config:     config,
+		reorgDepth: reorgDepth,
+		chain: NewChainFollower(api, ChainFollowerConfig{
+			StartHeight:      config.StartHeight,
+			PollInterval:     config.PollInterval,
+			ReorgDepth:       reorgDepth,
+			FullTransactions: true,
+			OnError:          config.OnError,
+		}),
+		payments:  make(chan Payment),
+		addresses: make(map[Address]struct{}),
+		mined:     make(map[string]int),
+		mempool:   make(map[string]struct{}),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	aw.Add(config.Addresses...)
+
+	go aw.run(ctx)
+
+	return aw
+}
+
+// Payments returns the channel on which the payments are emitted. The channel is closed when
+// the watcher is closed.
+func (aw *AddressWatcher) Payments() <-chan Payment {
+	return aw.payments
+}
+
+// Add adds addresses to the set of watched addresses
+func (aw *AddressWatcher) Add(addresses ...Address) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	for _, address := range addresses {
+		aw.addresses[address] = struct{}{}
+	}
+}
+
+// Remove removes addresses from the set of watched addresses. Payments to the addresses
+// that are awaiting confirmations are still emitted.
+func (aw *AddressWatcher) Remove(addresses ...Address) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	for _, address := range addresses {
+		delete(aw.addresses, address)
+	}
+}
+
+// Next returns the height of the next block that will be searched for payments
+func (aw *AddressWatcher) Next() int {
+	return aw.chain.Next()
+}
+
+// Close stops polling and closes the channel of payments
+func (aw *AddressWatcher) Close() {
+	aw.cancel()
+	aw.chain.Close()
+	<-aw.done
+}
+
+// run processes new blocks and polls the mempool until ctx is done
+func (aw *AddressWatcher) run(ctx context.Context) {
+	defer close(aw.done)
+	defer close(aw.payments)
+
+	var mempool <-chan time.Time
+	if aw.config.Mempool {
+		ticker := time.NewTicker(aw.config.PollInterval)
+		defer ticker.Stop()
+		mempool = ticker.C
+
+		aw.pollMempool(ctx)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-aw.chain.Events():
+			if !ok {
+				return
+			}
+			switch event.Type {
+			case BlockApplied:
+				aw.applyBlock(ctx, event.Block)
+			case BlockReverted:
+				aw.revertBlock(ctx, event.Block)
+			}
+		case <-mempool:
+			aw.pollMempool(ctx)
+		}
+	}
+}
+
+// applyBlock emits the payments that are included or confirmed by block
+func (aw *AddressWatcher) applyBlock(ctx context.Context, block Block) {
+	for hash, height := range aw.mined {
+		if height <= block.Number-aw.reorgDepth {
+			delete(aw.mined, hash)
+		}
+	}
+
+	confirming := aw.confirming[:0]
+	for _, payment := range aw.confirming {
+		payment.Confirmations = block.Number - payment.height + 1
+		if !aw.emit(ctx, payment.Payment) {
+			return
+		}
+		if payment.Confirmations < aw.config.Confirmations {
+			confirming = append(confirming, payment)
+		}
+	}
+	aw.confirming = confirming
+
+	for _, trn := range block.TransactionObjects {
+		address, ok := aw.watched(trn)
+		if !ok {
+			continue
+		}
+		delete(aw.mempool, trn.Hash)
+		aw.mined[trn.Hash] = block.Number
+
+		trn.BlockHash, trn.BlockNumber = block.Hash, block.Number
+		payment := confirmingPayment{
+			Payment: Payment{
+				Address:       address,
+				Transaction:   trn,
+				Confirmations: 1,
+			},
+			height: block.Number,
+		}
+		if !aw.emit(ctx, payment.Payment) {
+			return
+		}
+		if payment.Confirmations < aw.config.Confirmations {
+			aw.confirming = append(aw.confirming, payment)
+		}
+	}
+}
+
+// revertBlock emits the payments that were included by the orphaned block as reverted
+func (aw *AddressWatcher) revertBlock(ctx context.Context, block Block) {
+	confirming := aw.confirming[:0]
+	for _, payment := range aw.confirming {
+		if payment.height != block.Number {
+			confirming = append(confirming, payment)
+		}
+	}
+	aw.confirming = confirming
+
+	for _, trn := range block.TransactionObjects {
+		if _, ok := aw.mined[trn.Hash]; !ok {
+			continue
+		}
+		delete(aw.mined, trn.Hash)
+
+		address, _ := trn.ParsedTo()
+		trn.BlockHash, trn.BlockNumber = block.Hash, block.Number
+		if !aw.emit(ctx, Payment{Address: address, Transaction: trn, Reverted: true}) {
+			return
+		}
+	}
+}
+
+// pollMempool emits the payments in the mempool that were not emitted before
+func (aw *AddressWatcher) pollMempool(ctx context.Context) {
+	content, err := aw.api.MempoolContentContext(ctx, true)
+	if err != nil {
+		if ctx.Err() == nil && aw.config.OnError != nil {
+			aw.config.OnError(err)
+		}
+		return
+	}
+
+	// Only the hashes that are still in the mempool are kept, so the set does not grow
+	// with payments that were dropped
+	seen := make(map[string]struct{})
+	for _, trn := range content.TransactionObjects {
+		address, ok := aw.watched(trn)
+		if !ok {
+			continue
+		}
+		// A node may still report a transaction in its mempool after it was mined
+		if _, ok := aw.mined[trn.Hash]; ok {
+			continue
+		}
+		seen[trn.Hash] = struct{}{}
+		if _, ok := aw.mempool[trn.Hash]; ok {
+			continue
+		}
+
+		if !aw.emit(ctx, Payment{Address: address, Transaction: trn}) {
+			return
+		}
+	}
+	aw.mempool = seen
+}
+
+// watched returns the watched address that receives trn, if any
+func (aw *AddressWatcher) watched(trn Transaction) (Address, bool) {
+	to, err := trn.ParsedTo()
+	if err != nil {
+		return Address{}, false
+	}
+
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	_, ok := aw.addresses[to]
+	return to, ok
+}
+
+// emit sends the payment on the channel of payments. It returns false if ctx is done.
+func (aw *AddressWatcher) emit(ctx context.Context, payment Payment) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case aw.payments <- payment:
+		return true
+	}
+}
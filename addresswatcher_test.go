@@ -0,0 +1,186 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// expectPayment receives a payment from aw and checks its hash and confirmations
+func expectPayment(t *testing.T, aw *nimiqrpc.AddressWatcher, hash string, confirmations int) nimiqrpc.Payment {
+	t.Helper()
+	select {
+	case payment := <-aw.Payments():
+		if payment.Transaction.Hash != hash || payment.Confirmations != confirmations {
+			t.Fatalf("expected payment %s with %d confirmations, got %s with %d", hash, confirmations, payment.Transaction.Hash, payment.Confirmations)
+		}
+		return payment
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for payment %s with %d confirmations", hash, confirmations)
+	}
+	return nimiqrpc.Payment{}
+}
+
+func TestAddressWatcher(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+	carol := srv.NewAccount(0)
+
+	aw := nimiqrpc.NewAddressWatcher(client, nimiqrpc.AddressWatcherConfig{
+		Addresses:     []nimiqrpc.Address{nimiqrpc.MustParseAddress(bob.Address)},
+		StartHeight:   srv.BlockNumber() + 1,
+		PollInterval:  10 * time.Millisecond,
+		Confirmations: 2,
+		Mempool:       true,
+	})
+	defer aw.Close()
+
+	// Payments to unwatched addresses are ignored
+	if _, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: carol.Address, Value: 100, Fee: 138}); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: bob.Address, Value: 500, Fee: 138})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	payment := expectPayment(t, aw, hash, 0)
+	if payment.Address.String() != bob.Address || payment.Transaction.Value != 500 {
+		t.Errorf("unexpected payment %+v", payment)
+	}
+
+	srv.MineBlock()
+	expectPayment(t, aw, hash, 1)
+	srv.MineBlock()
+	expectPayment(t, aw, hash, 2)
+
+	// Addresses can be added and removed while watching
+	carolAddress, err := carol.ParsedAddress()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aw.Add(carolAddress)
+	srv.MineBlock()
+	hash, err = srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: carol.Address, Value: 200, Fee: 138})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment = expectPayment(t, aw, hash, 0)
+	if payment.Address != carolAddress {
+		t.Errorf("expected payment to %s, got %s", carolAddress, payment.Address)
+	}
+
+	aw.Remove(carolAddress)
+	srv.MineBlock()
+	srv.MineBlock()
+
+	select {
+	case payment := <-aw.Payments():
+		t.Errorf("unexpected payment to removed address %+v", payment)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAddressWatcherReorg(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	bob := nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	chain := &testChain{}
+	chain.serve(srv)
+	chain.extend(0, 1, "a")
+
+	aw := nimiqrpc.NewAddressWatcher(srv.Client(), nimiqrpc.AddressWatcherConfig{
+		Addresses:     []nimiqrpc.Address{bob},
+		StartHeight:   2,
+		PollInterval:  10 * time.Millisecond,
+		Confirmations: 3,
+	})
+	defer aw.Close()
+
+	// The transaction does not report its block number, the height of the block is used instead
+	payment, _ := json.Marshal([]nimiqrpc.Transaction{{Hash: "t1", ToAddress: bob.String(), Value: 500}})
+	chain.extend(1, 1, "a")
+	chain.mu.Lock()
+	chain.blocks[1].Transactions = payment
+	chain.mu.Unlock()
+
+	if p := expectPayment(t, aw, "t1", 1); p.Reverted || p.Transaction.BlockHash != "a2" || p.Transaction.BlockNumber != 2 {
+		t.Errorf("unexpected payment %+v", p)
+	}
+	chain.extend(2, 1, "a")
+	expectPayment(t, aw, "t1", 2)
+
+	// A fork without the transaction orphans the block that included it
+	chain.extend(1, 3, "b")
+	if p := expectPayment(t, aw, "t1", 0); !p.Reverted || p.Address != bob {
+		t.Errorf("expected reverted payment, got %+v", p)
+	}
+
+	select {
+	case p := <-aw.Payments():
+		t.Errorf("unexpected payment after reorg %+v", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAddressWatcherMinedInMempool(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+
+	aw := nimiqrpc.NewAddressWatcher(client, nimiqrpc.AddressWatcherConfig{
+		Addresses:    []nimiqrpc.Address{nimiqrpc.MustParseAddress(bob.Address)},
+		StartHeight:  srv.BlockNumber() + 1,
+		PollInterval: 10 * time.Millisecond,
+		Mempool:      true,
+	})
+	defer aw.Close()
+
+	hash, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: bob.Address, Value: 500, Fee: 138})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectPayment(t, aw, hash, 0)
+	srv.MineBlock()
+	expectPayment(t, aw, hash, 1)
+
+	// The node still reports the mined transaction in its mempool
+	trn, err := client.GetTransactionByHash(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.Handle("mempoolContent", func(params []json.RawMessage) (interface{}, error) {
+		return []nimiqrpc.Transaction{*trn}, nil
+	})
+
+	select {
+	case p := <-aw.Payments():
+		t.Errorf("unexpected payment of mined transaction %+v", p)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"context"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// CreateAccount creates a new account in the wallet of the node, encrypted with the given passphrase.
+func (c *Client) CreateAccount(passphrase string) (wallet *nimiqrpc.Wallet, err error) {
+	return c.CreateAccountContext(context.Background(), passphrase)
+}
+
+// CreateAccountContext is like CreateAccount but uses ctx for cancellation and deadlines.
+func (c *Client) CreateAccountContext(ctx context.Context, passphrase string) (wallet *nimiqrpc.Wallet, err error) {
+	var result nimiqrpc.Wallet
+	err = c.callFor(ctx, &result, nil, "createAccount", passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateBasicTransaction creates a basic transaction from an unlocked account of the node and returns
+// the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateBasicTransaction(wallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateBasicTransactionContext(context.Background(), wallet, recipient, value, fee, validityStartHeight)
+}
+
+// CreateBasicTransactionContext is like CreateBasicTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateBasicTransactionContext(ctx context.Context, wallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createBasicTransaction", wallet, recipient, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateBasicTransactionWithData is like CreateBasicTransaction but includes the hex-encoded data
+// in the transaction, which is usually a message for the recipient.
+func (c *Client) CreateBasicTransactionWithData(wallet nimiqrpc.Address, recipient nimiqrpc.Address, data string, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateBasicTransactionWithDataContext(context.Background(), wallet, recipient, data, value, fee, validityStartHeight)
+}
+
+// CreateBasicTransactionWithDataContext is like CreateBasicTransactionWithData but uses ctx for cancellation and deadlines.
+func (c *Client) CreateBasicTransactionWithDataContext(ctx context.Context, wallet nimiqrpc.Address, recipient nimiqrpc.Address, data string, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createBasicTransactionWithData", wallet, recipient, data, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// GetAccountByAddress returns details for the account of given address, and the state of the chain
+// at which the account was read.
+func (c *Client) GetAccountByAddress(address nimiqrpc.Address) (account *Account, state *BlockchainState, err error) {
+	return c.GetAccountByAddressContext(context.Background(), address)
+}
+
+// GetAccountByAddressContext is like GetAccountByAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetAccountByAddressContext(ctx context.Context, address nimiqrpc.Address) (account *Account, state *BlockchainState, err error) {
+	var result Account
+	var metadata BlockchainState
+	err = c.callFor(ctx, &result, &metadata, "getAccountByAddress", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &result, &metadata, nil
+}
+
+// GetBatchNumber returns the number of the batch of the most recent block.
+func (c *Client) GetBatchNumber() (batchNumber int, err error) {
+	return c.GetBatchNumberContext(context.Background())
+}
+
+// GetBatchNumberContext is like GetBatchNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetBatchNumberContext(ctx context.Context) (batchNumber int, err error) {
+	err = c.callFor(ctx, &batchNumber, nil, "getBatchNumber")
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// GetBlockByHash returns information about a block by block hash.
+// If includeBody is true the transactions of the block are included.
+func (c *Client) GetBlockByHash(blockHash string, includeBody bool) (block *Block, err error) {
+	return c.GetBlockByHashContext(context.Background(), blockHash, includeBody)
+}
+
+// GetBlockByHashContext is like GetBlockByHash but uses ctx for cancellation and deadlines.
+func (c *Client) GetBlockByHashContext(ctx context.Context, blockHash string, includeBody bool) (block *Block, err error) {
+	var result Block
+	err = c.callFor(ctx, &result, nil, "getBlockByHash", blockHash, includeBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBlockByNumber returns information about a block by block number.
+// If includeBody is true the transactions of the block are included.
+func (c *Client) GetBlockByNumber(blockNumber int, includeBody bool) (block *Block, err error) {
+	return c.GetBlockByNumberContext(context.Background(), blockNumber, includeBody)
+}
+
+// GetBlockByNumberContext is like GetBlockByNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetBlockByNumberContext(ctx context.Context, blockNumber int, includeBody bool) (block *Block, err error) {
+	var result Block
+	err = c.callFor(ctx, &result, nil, "getBlockByNumber", blockNumber, includeBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetBlockNumber returns the height of the most recent block.
+func (c *Client) GetBlockNumber() (blockNumber int, err error) {
+	return c.GetBlockNumberContext(context.Background())
+}
+
+// GetBlockNumberContext is like GetBlockNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetBlockNumberContext(ctx context.Context) (blockNumber int, err error) {
+	err = c.callFor(ctx, &blockNumber, nil, "getBlockNumber")
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// GetEpochNumber returns the number of the epoch of the most recent block.
+func (c *Client) GetEpochNumber() (epochNumber int, err error) {
+	return c.GetEpochNumberContext(context.Background())
+}
+
+// GetEpochNumberContext is like GetEpochNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetEpochNumberContext(ctx context.Context) (epochNumber int, err error) {
+	err = c.callFor(ctx, &epochNumber, nil, "getEpochNumber")
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// GetLatestBlock returns information about the most recent block.
+// If includeBody is true the transactions of the block are included.
+func (c *Client) GetLatestBlock(includeBody bool) (block *Block, err error) {
+	return c.GetLatestBlockContext(context.Background(), includeBody)
+}
+
+// GetLatestBlockContext is like GetLatestBlock but uses ctx for cancellation and deadlines.
+func (c *Client) GetLatestBlockContext(ctx context.Context, includeBody bool) (block *Block, err error) {
+	var result Block
+	err = c.callFor(ctx, &result, nil, "getLatestBlock", includeBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetPeerCount returns the number of peers currently connected to the node.
+func (c *Client) GetPeerCount() (peers int, err error) {
+	return c.GetPeerCountContext(context.Background())
+}
+
+// GetPeerCountContext is like GetPeerCount but uses ctx for cancellation and deadlines.
+func (c *Client) GetPeerCountContext(ctx context.Context) (peers int, err error) {
+	err = c.callFor(ctx, &peers, nil, "getPeerCount")
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// GetPeerID returns the peer ID of the node.
+func (c *Client) GetPeerID() (peerID string, err error) {
+	return c.GetPeerIDContext(context.Background())
+}
+
+// GetPeerIDContext is like GetPeerID but uses ctx for cancellation and deadlines.
+func (c *Client) GetPeerIDContext(ctx context.Context) (peerID string, err error) {
+	err = c.callFor(ctx, &peerID, nil, "getPeerId")
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// GetPeerList returns the peer IDs of the peers currently connected to the node.
+func (c *Client) GetPeerList() (peers []string, err error) {
+	return c.GetPeerListContext(context.Background())
+}
+
+// GetPeerListContext is like GetPeerList but uses ctx for cancellation and deadlines.
+func (c *Client) GetPeerListContext(ctx context.Context) (peers []string, err error) {
+	err = c.callFor(ctx, &peers, nil, "getPeerList")
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GetRawTransactionInfo deserializes the hex-encoded transaction and returns information about it.
+func (c *Client) GetRawTransactionInfo(transactionHex string) (transaction *Transaction, err error) {
+	return c.GetRawTransactionInfoContext(context.Background(), transactionHex)
+}
+
+// GetRawTransactionInfoContext is like GetRawTransactionInfo but uses ctx for cancellation and deadlines.
+func (c *Client) GetRawTransactionInfoContext(ctx context.Context, transactionHex string) (transaction *Transaction, err error) {
+	var result Transaction
+	err = c.callFor(ctx, &result, nil, "getRawTransactionInfo", transactionHex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetTransactionByHash returns the information about a transaction by transaction hash.
+func (c *Client) GetTransactionByHash(transactionHash string) (transaction *Transaction, err error) {
+	return c.GetTransactionByHashContext(context.Background(), transactionHash)
+}
+
+// GetTransactionByHashContext is like GetTransactionByHash but uses ctx for cancellation and deadlines.
+func (c *Client) GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *Transaction, err error) {
+	var result Transaction
+	err = c.callFor(ctx, &result, nil, "getTransactionByHash", transactionHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetTransactionHashesByAddress returns the hashes of the latest transactions of the given address,
+// at most maxEntries.
+func (c *Client) GetTransactionHashesByAddress(address nimiqrpc.Address, maxEntries int) (transactionHashes []string, err error) {
+	return c.GetTransactionHashesByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionHashesByAddressContext is like GetTransactionHashesByAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetTransactionHashesByAddressContext(ctx context.Context, address nimiqrpc.Address, maxEntries int) (transactionHashes []string, err error) {
+	err = c.callFor(ctx, &transactionHashes, nil, "getTransactionHashesByAddress", address, maxEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GetTransactionsByAddress returns the latest transactions of the given address, at most maxEntries.
+func (c *Client) GetTransactionsByAddress(address nimiqrpc.Address, maxEntries int) (transactions []Transaction, err error) {
+	return c.GetTransactionsByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionsByAddressContext is like GetTransactionsByAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetTransactionsByAddressContext(ctx context.Context, address nimiqrpc.Address, maxEntries int) (transactions []Transaction, err error) {
+	err = c.callFor(ctx, &transactions, nil, "getTransactionsByAddress", address, maxEntries)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GetTransactionsByBatchNumber returns the transactions of the batch with the given number.
+func (c *Client) GetTransactionsByBatchNumber(batchNumber int) (transactions []Transaction, err error) {
+	return c.GetTransactionsByBatchNumberContext(context.Background(), batchNumber)
+}
+
+// GetTransactionsByBatchNumberContext is like GetTransactionsByBatchNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetTransactionsByBatchNumberContext(ctx context.Context, batchNumber int) (transactions []Transaction, err error) {
+	err = c.callFor(ctx, &transactions, nil, "getTransactionsByBatchNumber", batchNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// GetTransactionsByBlockNumber returns the transactions of the block with the given number.
+func (c *Client) GetTransactionsByBlockNumber(blockNumber int) (transactions []Transaction, err error) {
+	return c.GetTransactionsByBlockNumberContext(context.Background(), blockNumber)
+}
+
+// GetTransactionsByBlockNumberContext is like GetTransactionsByBlockNumber but uses ctx for cancellation and deadlines.
+func (c *Client) GetTransactionsByBlockNumberContext(ctx context.Context, blockNumber int) (transactions []Transaction, err error) {
+	err = c.callFor(ctx, &transactions, nil, "getTransactionsByBlockNumber", blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// ImportRawKey imports the hex-encoded Ed25519 private key into the wallet of the node, encrypted
+// with the given passphrase, and returns the address of the account.
+func (c *Client) ImportRawKey(keyData string, passphrase string) (address nimiqrpc.Address, err error) {
+	return c.ImportRawKeyContext(context.Background(), keyData, passphrase)
+}
+
+// ImportRawKeyContext is like ImportRawKey but uses ctx for cancellation and deadlines.
+func (c *Client) ImportRawKeyContext(ctx context.Context, keyData string, passphrase string) (address nimiqrpc.Address, err error) {
+	err = c.callFor(ctx, &address, nil, "importRawKey", keyData, passphrase)
+	if err != nil {
+		return nimiqrpc.Address{}, err
+	}
+
+	return
+}
+
+// IsAccountUnlocked returns whether the account of the given address is unlocked in the wallet of the node.
+func (c *Client) IsAccountUnlocked(address nimiqrpc.Address) (unlocked bool, err error) {
+	return c.IsAccountUnlockedContext(context.Background(), address)
+}
+
+// IsAccountUnlockedContext is like IsAccountUnlocked but uses ctx for cancellation and deadlines.
+func (c *Client) IsAccountUnlockedContext(ctx context.Context, address nimiqrpc.Address) (unlocked bool, err error) {
+	err = c.callFor(ctx, &unlocked, nil, "isAccountUnlocked", address)
+	if err != nil {
+		return false, err
+	}
+
+	return
+}
+
+// IsConsensusEstablished returns whether the node is in sync with the network.
+func (c *Client) IsConsensusEstablished() (established bool, err error) {
+	return c.IsConsensusEstablishedContext(context.Background())
+}
+
+// IsConsensusEstablishedContext is like IsConsensusEstablished but uses ctx for cancellation and deadlines.
+func (c *Client) IsConsensusEstablishedContext(ctx context.Context) (established bool, err error) {
+	err = c.callFor(ctx, &established, nil, "isConsensusEstablished")
+	if err != nil {
+		return false, err
+	}
+
+	return
+}
+
+// ListAccounts returns the addresses of the accounts in the wallet of the node.
+func (c *Client) ListAccounts() (addresses []nimiqrpc.Address, err error) {
+	return c.ListAccountsContext(context.Background())
+}
+
+// ListAccountsContext is like ListAccounts but uses ctx for cancellation and deadlines.
+func (c *Client) ListAccountsContext(ctx context.Context) (addresses []nimiqrpc.Address, err error) {
+	err = c.callFor(ctx, &addresses, nil, "listAccounts")
+	if err != nil {
+		return nil, err
+	}
+
+	return
+}
+
+// LockAccount locks the account of the given address in the wallet of the node.
+func (c *Client) LockAccount(address nimiqrpc.Address) (err error) {
+	return c.LockAccountContext(context.Background(), address)
+}
+
+// LockAccountContext is like LockAccount but uses ctx for cancellation and deadlines.
+func (c *Client) LockAccountContext(ctx context.Context, address nimiqrpc.Address) (err error) {
+	return c.callFor(ctx, nil, nil, "lockAccount", address)
+}
+
+// SendBasicTransaction sends a basic transaction from an unlocked account of the node and returns its hash.
+func (c *Client) SendBasicTransaction(wallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendBasicTransactionContext(context.Background(), wallet, recipient, value, fee, validityStartHeight)
+}
+
+// SendBasicTransactionContext is like SendBasicTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendBasicTransactionContext(ctx context.Context, wallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendBasicTransaction", wallet, recipient, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendBasicTransactionWithData is like SendBasicTransaction but includes the hex-encoded data
+// in the transaction, which is usually a message for the recipient.
+func (c *Client) SendBasicTransactionWithData(wallet nimiqrpc.Address, recipient nimiqrpc.Address, data string, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendBasicTransactionWithDataContext(context.Background(), wallet, recipient, data, value, fee, validityStartHeight)
+}
+
+// SendBasicTransactionWithDataContext is like SendBasicTransactionWithData but uses ctx for cancellation and deadlines.
+func (c *Client) SendBasicTransactionWithDataContext(ctx context.Context, wallet nimiqrpc.Address, recipient nimiqrpc.Address, data string, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendBasicTransactionWithData", wallet, recipient, data, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendRawTransaction sends a hex-encoded signed transaction to the network and returns its hash.
+func (c *Client) SendRawTransaction(transactionHex string) (transactionHash string, err error) {
+	return c.SendRawTransactionContext(context.Background(), transactionHex)
+}
+
+// SendRawTransactionContext is like SendRawTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendRawTransactionContext(ctx context.Context, transactionHex string) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendRawTransaction", transactionHex)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// UnlockAccount unlocks the account of the given address in the wallet of the node, so it can be
+// used to send transactions. It returns whether the account was unlocked.
+func (c *Client) UnlockAccount(address nimiqrpc.Address, passphrase string) (unlocked bool, err error) {
+	return c.UnlockAccountContext(context.Background(), address, passphrase)
+}
+
+// UnlockAccountContext is like UnlockAccount but uses ctx for cancellation and deadlines.
+func (c *Client) UnlockAccountContext(ctx context.Context, address nimiqrpc.Address, passphrase string) (unlocked bool, err error) {
+	err = c.callFor(ctx, &unlocked, nil, "unlockAccount", address, passphrase, nil)
+	if err != nil {
+		return false, err
+	}
+
+	return
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// Client is a client for the JSON-RPC API of Nimiq Albatross nodes
+type Client struct {
+	rpc *nimiqrpc.Client
+	ws  *nimiqrpc.WebSocketClient // set if the client is connected over WebSocket
+}
+
+// NewClient returns a new Albatross RPC client. The options of the nimiqrpc package are
+// used to configure the underlying nimiqrpc.Client.
+func NewClient(address string, opts ...nimiqrpc.Option) *Client {
+	return NewClientFrom(nimiqrpc.NewClient(address, opts...))
+}
+
+// NewClientFrom returns a new Albatross RPC client that sends its calls with nc.
+func NewClientFrom(nc *nimiqrpc.Client) *Client {
+	return &Client{
+		rpc: nc,
+	}
+}
+
+// DialWebSocket connects to the Albatross node at the given WebSocket URL, for example
+// "ws://localhost:8648/ws", and returns a Client that sends its calls over the connection.
+// Only a Client connected over WebSocket supports subscriptions.
+func DialWebSocket(ctx context.Context, url string, opts ...nimiqrpc.Option) (*Client, error) {
+	wc, err := nimiqrpc.DialWebSocket(ctx, url, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewClientFromWebSocket(wc), nil
+}
+
+// NewClientFromWebSocket returns a new Albatross RPC client that sends its calls over the
+// connection of wc.
+func NewClientFromWebSocket(wc *nimiqrpc.WebSocketClient) *Client {
+	return &Client{
+		rpc: wc.Client,
+		ws:  wc,
+	}
+}
+
+// Close closes the WebSocket connection of the client and ends all subscriptions.
+// It has no effect on clients that are not connected over WebSocket.
+func (c *Client) Close() error {
+	if c.ws == nil {
+		return nil
+	}
+	return c.ws.Close()
+}
+
+// RPC returns the underlying nimiqrpc.Client, which can be used for calls that are not
+// covered by this package. Its results are not unwrapped from the data envelope.
+func (c *Client) RPC() *nimiqrpc.Client {
+	return c.rpc
+}
+
+// envelope is the wrapper of every result returned by an Albatross node
+type envelope struct {
+	Data     json.RawMessage `json:"data"`
+	Metadata json.RawMessage `json:"metadata"`
+}
+
+// callFor does an RPC call and decodes the data of the result into out. If metadata is not nil,
+// the metadata of the result is decoded into it. JSON-RPC errors returned by the node are
+// returned as *nimiqrpc.RPCError.
+func (c *Client) callFor(ctx context.Context, out interface{}, metadata interface{}, method string, params ...interface{}) error {
+	// Albatross nodes expect the parameters as an array, also when there are none or only one
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	rpcResp, err := c.rpc.CallContext(ctx, method, params)
+	if err != nil {
+		return err
+	}
+	if rpcResp.Error != nil {
+		return &nimiqrpc.RPCError{
+			Method:  method,
+			Code:    rpcResp.Error.Code,
+			Message: rpcResp.Error.Message,
+			Data:    rpcResp.Error.Data,
+		}
+	}
+
+	var result envelope
+	err = rpcResp.GetObject(&result)
+	if err != nil {
+		return fmt.Errorf("%w: %v", nimiqrpc.ErrResultUnexpected, err)
+	}
+
+	if out != nil {
+		err = unmarshal(result.Data, out)
+		if err != nil {
+			return err
+		}
+	}
+	if metadata != nil {
+		err = unmarshal(result.Metadata, metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unmarshal decodes a part of a result. A missing part leaves v untouched.
+func unmarshal(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	err := json.Unmarshal(data, v)
+	if err != nil {
+		return fmt.Errorf("%w: %v", nimiqrpc.ErrResultUnexpected, err)
+	}
+
+	return nil
+}
+
+// optional returns nil for an empty optional parameter, so it is sent as null
+func optional(param string) interface{} {
+	if param == "" {
+		return nil
+	}
+	return param
+}
+
+// optionalAddress returns nil for a nil optional address parameter, so it is sent as null
+func optionalAddress(param *nimiqrpc.Address) interface{} {
+	if param == nil {
+		return nil
+	}
+	return *param
+}
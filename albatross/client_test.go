@@ -0,0 +1,154 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// fakeNode is a test server that responds to every method with a scripted result envelope,
+// and records the parameters of the last call of every method
+type fakeNode struct {
+	*httptest.Server
+	results map[string]string
+	params  map[string]string
+}
+
+// newFakeNode returns a fakeNode responding with the given raw JSON results by method
+func newFakeNode(results map[string]string) *fakeNode {
+	node := &fakeNode{
+		results: results,
+		params:  make(map[string]string),
+	}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     int             `json:"id"`
+			Method string          `json:"method"`
+			Params json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		node.params[req.Method] = string(req.Params)
+
+		result, ok := node.results[req.Method]
+		if !ok {
+			w.Write([]byte(`{"jsonrpc":"2.0","id":0,"error":{"code":-32601,"message":"Method not found"}}`))
+			return
+		}
+		w.Write([]byte(`{"jsonrpc":"2.0","id":0,"result":` + result + `}`))
+	}))
+	return node
+}
+
+func TestClientEnvelope(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"getBlockNumber":      `{"data":1234,"metadata":null}`,
+		"getLatestBlock":      `{"data":{"hash":"abc","number":1234,"batch":20,"epoch":1,"type":"micro","producer":{"slotNumber":7,"validator":"NQ07 0000 0000 0000 0000 0000 0000 0000 0000","publicKey":"00"}},"metadata":null}`,
+		"getAccountByAddress": `{"data":{"address":"NQ07 0000 0000 0000 0000 0000 0000 0000 0000","balance":100000,"type":"basic"},"metadata":{"blockNumber":1234,"blockHash":"abc"}}`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	number, err := client.GetBlockNumber()
+	if err != nil || number != 1234 {
+		t.Errorf("GetBlockNumber: got %d, %v", number, err)
+	}
+	if node.params["getBlockNumber"] != "[]" {
+		t.Errorf("expected empty params array, got %s", node.params["getBlockNumber"])
+	}
+
+	block, err := client.GetLatestBlock(false)
+	if err != nil || block.Number != 1234 || block.Type != BlockTypeMicro || block.Producer == nil || block.Producer.SlotNumber != 7 {
+		t.Errorf("GetLatestBlock: got %+v, %v", block, err)
+	}
+	if node.params["getLatestBlock"] != "[false]" {
+		t.Errorf("expected params [false], got %s", node.params["getLatestBlock"])
+	}
+
+	account, state, err := client.GetAccountByAddress(nimiqrpc.MustParseAddress("NQ07 0000 0000 0000 0000 0000 0000 0000 0000"))
+	if err != nil || account.Balance != 100000 || account.Type != AccountTypeBasic {
+		t.Errorf("GetAccountByAddress: got %+v, %v", account, err)
+	}
+	if state == nil || state.BlockNumber != 1234 || state.BlockHash != "abc" {
+		t.Errorf("GetAccountByAddress: unexpected metadata %+v", state)
+	}
+}
+
+func TestClientSendBasicTransaction(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"sendBasicTransaction": `{"data":"0123","metadata":null}`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	hash, err := client.SendBasicTransaction(validatorAddress, rewardAddress, 100, 2, RelativeHeight(0))
+	if err != nil || hash != "0123" {
+		t.Fatalf("SendBasicTransaction: got %s, %v", hash, err)
+	}
+	if params := node.params["sendBasicTransaction"]; params != `["NQ43 040G 2081 040G 2081 040G 2081 040G 2081","NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2",100,2,"+0"]` {
+		t.Errorf("unexpected params %s", params)
+	}
+
+	_, err = client.SendBasicTransaction(validatorAddress, rewardAddress, 100, 2, AbsoluteHeight(42))
+	if params := node.params["sendBasicTransaction"]; err != nil || params != `["NQ43 040G 2081 040G 2081 040G 2081 040G 2081","NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2",100,2,42]` {
+		t.Errorf("unexpected params %s, %v", params, err)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"getEpochNumber": `42`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	_, err := client.GetBatchNumber()
+	var rpcErr *nimiqrpc.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Method != "getBatchNumber" || rpcErr.Code != -32601 {
+		t.Errorf("expected *nimiqrpc.RPCError, got %v", err)
+	}
+
+	_, err = client.GetEpochNumber()
+	if !errors.Is(err, nimiqrpc.ErrResultUnexpected) {
+		t.Errorf("expected %v, got %v", nimiqrpc.ErrResultUnexpected, err)
+	}
+}
+
+func TestClientGetTransactionsByAddress(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"getTransactionsByAddress": `{"data":[{"hash":"abc","from":"NQ43 040G 2081 040G 2081 040G 2081 040G 2081","fromType":"basic","to":"NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2","toType":"basic","relatedAddresses":["NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2"],"value":100,"fee":2}],"metadata":null}`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	transactions, err := client.GetTransactionsByAddress(rewardAddress, 10)
+	if err != nil || len(transactions) != 1 {
+		t.Fatalf("GetTransactionsByAddress: got %+v, %v", transactions, err)
+	}
+	if trn := transactions[0]; trn.From != validatorAddress || trn.To != rewardAddress || len(trn.RelatedAddresses) != 1 || trn.RelatedAddresses[0] != rewardAddress {
+		t.Errorf("unexpected transaction %+v", trn)
+	}
+	if params := node.params["getTransactionsByAddress"]; params != `["NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2",10]` {
+		t.Errorf("unexpected params %s", params)
+	}
+}
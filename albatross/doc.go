@@ -0,0 +1,45 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package albatross provides a client for the JSON-RPC API of Nimiq Albatross nodes.
+
+Albatross is the Proof-of-Stake chain of Nimiq. Its JSON-RPC API, as implemented by core-rs-albatross,
+uses different method names and parameters than the Proof-of-Work API of core-js, and wraps every
+result in an envelope of data and metadata. This package provides a Client for the Albatross API
+that is built on top of the nimiqrpc.Client, so it supports the same options, retry policies and
+typed errors. Amounts are expressed in nimiqrpc.Luna, so code can migrate incrementally.
+
+How to use this package:
+
+  // Initialise a new client
+  albatrossClient := albatross.NewClient("address.to.albatrossnode.com")
+
+  // Retrieve the most recent block, without its transactions
+  block, err := albatrossClient.GetLatestBlock(false)
+  if err != nil {
+      panic(err)
+  }
+  fmt.Printf("Block %d of epoch %d\n", block.Number, block.Epoch)
+
+  // Send 1 NIM from an unlocked account of the node
+  hash, err := albatrossClient.SendBasicTransaction(
+      nimiqrpc.MustParseAddress("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"),
+      nimiqrpc.MustParseAddress("NQ07 0000 0000 0000 0000 0000 0000 0000 0000"),
+      100000, 0, albatross.RelativeHeight(0),
+  )
+
+*/
+package albatross
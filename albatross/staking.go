@@ -0,0 +1,410 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"context"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// GetActiveValidators returns the validators that are active in the current epoch, and the state
+// of the chain at which they were read.
+func (c *Client) GetActiveValidators() (validators []Validator, state *BlockchainState, err error) {
+	return c.GetActiveValidatorsContext(context.Background())
+}
+
+// GetActiveValidatorsContext is like GetActiveValidators but uses ctx for cancellation and deadlines.
+func (c *Client) GetActiveValidatorsContext(ctx context.Context) (validators []Validator, state *BlockchainState, err error) {
+	var metadata BlockchainState
+	err = c.callFor(ctx, &validators, &metadata, "getActiveValidators")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return validators, &metadata, nil
+}
+
+// GetSlotAt returns the slot of the validator that produced, or is expected to produce, the block
+// at the given height.
+func (c *Client) GetSlotAt(blockNumber int) (slot *Slot, err error) {
+	return c.GetSlotAtContext(context.Background(), blockNumber)
+}
+
+// GetSlotAtContext is like GetSlotAt but uses ctx for cancellation and deadlines.
+func (c *Client) GetSlotAtContext(ctx context.Context, blockNumber int) (slot *Slot, err error) {
+	var result Slot
+	err = c.callFor(ctx, &result, nil, "getSlotAt", blockNumber, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetStakerByAddress returns details for the staker of the given address, and the state of the
+// chain at which the staker was read.
+func (c *Client) GetStakerByAddress(address string) (staker *Staker, state *BlockchainState, err error) {
+	return c.GetStakerByAddressContext(context.Background(), address)
+}
+
+// GetStakerByAddressContext is like GetStakerByAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetStakerByAddressContext(ctx context.Context, address string) (staker *Staker, state *BlockchainState, err error) {
+	var result Staker
+	var metadata BlockchainState
+	err = c.callFor(ctx, &result, &metadata, "getStakerByAddress", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &result, &metadata, nil
+}
+
+// GetStakersByValidatorAddress returns the stakers that delegate their stake to the validator of the
+// given address, and the state of the chain at which they were read.
+func (c *Client) GetStakersByValidatorAddress(address string) (stakers []Staker, state *BlockchainState, err error) {
+	return c.GetStakersByValidatorAddressContext(context.Background(), address)
+}
+
+// GetStakersByValidatorAddressContext is like GetStakersByValidatorAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetStakersByValidatorAddressContext(ctx context.Context, address string) (stakers []Staker, state *BlockchainState, err error) {
+	var metadata BlockchainState
+	err = c.callFor(ctx, &stakers, &metadata, "getStakersByValidatorAddress", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return stakers, &metadata, nil
+}
+
+// GetValidatorByAddress returns details for the validator of the given address, and the state of the
+// chain at which the validator was read.
+func (c *Client) GetValidatorByAddress(address string) (validator *Validator, state *BlockchainState, err error) {
+	return c.GetValidatorByAddressContext(context.Background(), address)
+}
+
+// GetValidatorByAddressContext is like GetValidatorByAddress but uses ctx for cancellation and deadlines.
+func (c *Client) GetValidatorByAddressContext(ctx context.Context, address string) (validator *Validator, state *BlockchainState, err error) {
+	var result Validator
+	var metadata BlockchainState
+	err = c.callFor(ctx, &result, &metadata, "getValidatorByAddress", address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &result, &metadata, nil
+}
+
+// CreateNewStakerTransaction creates a transaction that creates a staker for stakerWallet, funded by senderWallet with the given value, that
+// delegates its stake to the validator of the delegation address. delegation may be nil.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateNewStakerTransaction(senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, delegation *nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateNewStakerTransactionContext(context.Background(), senderWallet, stakerWallet, delegation, value, fee, validityStartHeight)
+}
+
+// CreateNewStakerTransactionContext is like CreateNewStakerTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateNewStakerTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, delegation *nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createNewStakerTransaction", senderWallet, stakerWallet, optionalAddress(delegation), value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendNewStakerTransaction sends a transaction that creates a staker for stakerWallet, funded by senderWallet with the given value, that
+// delegates its stake to the validator of the delegation address. delegation may be nil.
+// It returns the hash of the transaction.
+func (c *Client) SendNewStakerTransaction(senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, delegation *nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendNewStakerTransactionContext(context.Background(), senderWallet, stakerWallet, delegation, value, fee, validityStartHeight)
+}
+
+// SendNewStakerTransactionContext is like SendNewStakerTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendNewStakerTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, delegation *nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendNewStakerTransaction", senderWallet, stakerWallet, optionalAddress(delegation), value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateStakeTransaction creates a transaction that adds the given value from senderWallet to the stake of the staker of stakerAddress.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateStakeTransaction(senderWallet nimiqrpc.Address, stakerAddress nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateStakeTransactionContext(context.Background(), senderWallet, stakerAddress, value, fee, validityStartHeight)
+}
+
+// CreateStakeTransactionContext is like CreateStakeTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateStakeTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerAddress nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createStakeTransaction", senderWallet, stakerAddress, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendStakeTransaction sends a transaction that adds the given value from senderWallet to the stake of the staker of stakerAddress.
+// It returns the hash of the transaction.
+func (c *Client) SendStakeTransaction(senderWallet nimiqrpc.Address, stakerAddress nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendStakeTransactionContext(context.Background(), senderWallet, stakerAddress, value, fee, validityStartHeight)
+}
+
+// SendStakeTransactionContext is like SendStakeTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendStakeTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerAddress nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendStakeTransaction", senderWallet, stakerAddress, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateUpdateStakerTransaction creates a transaction that changes the validator the staker of stakerWallet delegates its stake to, paid by
+// senderWallet. newDelegation may be nil to remove the delegation. If reactivateAllStake is true,
+// the inactive stake of the staker is reactivated.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateUpdateStakerTransaction(senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, newDelegation *nimiqrpc.Address, reactivateAllStake bool, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateUpdateStakerTransactionContext(context.Background(), senderWallet, stakerWallet, newDelegation, reactivateAllStake, fee, validityStartHeight)
+}
+
+// CreateUpdateStakerTransactionContext is like CreateUpdateStakerTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateUpdateStakerTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, newDelegation *nimiqrpc.Address, reactivateAllStake bool, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createUpdateStakerTransaction", senderWallet, stakerWallet, optionalAddress(newDelegation), reactivateAllStake, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendUpdateStakerTransaction sends a transaction that changes the validator the staker of stakerWallet delegates its stake to, paid by
+// senderWallet. newDelegation may be nil to remove the delegation. If reactivateAllStake is true,
+// the inactive stake of the staker is reactivated.
+// It returns the hash of the transaction.
+func (c *Client) SendUpdateStakerTransaction(senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, newDelegation *nimiqrpc.Address, reactivateAllStake bool, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendUpdateStakerTransactionContext(context.Background(), senderWallet, stakerWallet, newDelegation, reactivateAllStake, fee, validityStartHeight)
+}
+
+// SendUpdateStakerTransactionContext is like SendUpdateStakerTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendUpdateStakerTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, stakerWallet nimiqrpc.Address, newDelegation *nimiqrpc.Address, reactivateAllStake bool, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendUpdateStakerTransaction", senderWallet, stakerWallet, optionalAddress(newDelegation), reactivateAllStake, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateUnstakeTransaction creates a transaction that removes the given value from the stake of the staker of stakerWallet and sends it to recipient.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateUnstakeTransaction(stakerWallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateUnstakeTransactionContext(context.Background(), stakerWallet, recipient, value, fee, validityStartHeight)
+}
+
+// CreateUnstakeTransactionContext is like CreateUnstakeTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateUnstakeTransactionContext(ctx context.Context, stakerWallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createUnstakeTransaction", stakerWallet, recipient, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendUnstakeTransaction sends a transaction that removes the given value from the stake of the staker of stakerWallet and sends it to recipient.
+// It returns the hash of the transaction.
+func (c *Client) SendUnstakeTransaction(stakerWallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendUnstakeTransactionContext(context.Background(), stakerWallet, recipient, value, fee, validityStartHeight)
+}
+
+// SendUnstakeTransactionContext is like SendUnstakeTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendUnstakeTransactionContext(ctx context.Context, stakerWallet nimiqrpc.Address, recipient nimiqrpc.Address, value nimiqrpc.Luna, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendUnstakeTransaction", stakerWallet, recipient, value, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateNewValidatorTransaction creates a transaction that registers the account of validatorWallet as a validator, paid by senderWallet.
+// The secret keys are hex-encoded, signalData is hex-encoded and may be empty.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateNewValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, signingSecretKey string, votingSecretKey string, rewardAddress nimiqrpc.Address, signalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateNewValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, signingSecretKey, votingSecretKey, rewardAddress, signalData, fee, validityStartHeight)
+}
+
+// CreateNewValidatorTransactionContext is like CreateNewValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateNewValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, signingSecretKey string, votingSecretKey string, rewardAddress nimiqrpc.Address, signalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createNewValidatorTransaction", senderWallet, validatorWallet, signingSecretKey, votingSecretKey, rewardAddress, signalData, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendNewValidatorTransaction sends a transaction that registers the account of validatorWallet as a validator, paid by senderWallet.
+// The secret keys are hex-encoded, signalData is hex-encoded and may be empty.
+// It returns the hash of the transaction.
+func (c *Client) SendNewValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, signingSecretKey string, votingSecretKey string, rewardAddress nimiqrpc.Address, signalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendNewValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, signingSecretKey, votingSecretKey, rewardAddress, signalData, fee, validityStartHeight)
+}
+
+// SendNewValidatorTransactionContext is like SendNewValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendNewValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, signingSecretKey string, votingSecretKey string, rewardAddress nimiqrpc.Address, signalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendNewValidatorTransaction", senderWallet, validatorWallet, signingSecretKey, votingSecretKey, rewardAddress, signalData, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateUpdateValidatorTransaction creates a transaction that changes the keys, reward address or signal data of the validator of validatorWallet,
+// paid by senderWallet. Empty or nil parameters leave the corresponding value unchanged.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateUpdateValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, newSigningSecretKey string, newVotingSecretKey string, newRewardAddress *nimiqrpc.Address, newSignalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateUpdateValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, newSigningSecretKey, newVotingSecretKey, newRewardAddress, newSignalData, fee, validityStartHeight)
+}
+
+// CreateUpdateValidatorTransactionContext is like CreateUpdateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateUpdateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, newSigningSecretKey string, newVotingSecretKey string, newRewardAddress *nimiqrpc.Address, newSignalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createUpdateValidatorTransaction", senderWallet, validatorWallet, optional(newSigningSecretKey), optional(newVotingSecretKey), optionalAddress(newRewardAddress), optional(newSignalData), fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendUpdateValidatorTransaction sends a transaction that changes the keys, reward address or signal data of the validator of validatorWallet,
+// paid by senderWallet. Empty or nil parameters leave the corresponding value unchanged.
+// It returns the hash of the transaction.
+func (c *Client) SendUpdateValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, newSigningSecretKey string, newVotingSecretKey string, newRewardAddress *nimiqrpc.Address, newSignalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendUpdateValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, newSigningSecretKey, newVotingSecretKey, newRewardAddress, newSignalData, fee, validityStartHeight)
+}
+
+// SendUpdateValidatorTransactionContext is like SendUpdateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendUpdateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, newSigningSecretKey string, newVotingSecretKey string, newRewardAddress *nimiqrpc.Address, newSignalData string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendUpdateValidatorTransaction", senderWallet, validatorWallet, optional(newSigningSecretKey), optional(newVotingSecretKey), optionalAddress(newRewardAddress), optional(newSignalData), fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateDeactivateValidatorTransaction creates a transaction that deactivates the validator of validatorAddress, so it is not elected for new epochs,
+// paid by senderWallet. The transaction is signed with the hex-encoded signing secret key of the validator.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateDeactivateValidatorTransaction(senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateDeactivateValidatorTransactionContext(context.Background(), senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+}
+
+// CreateDeactivateValidatorTransactionContext is like CreateDeactivateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateDeactivateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createDeactivateValidatorTransaction", senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendDeactivateValidatorTransaction sends a transaction that deactivates the validator of validatorAddress, so it is not elected for new epochs,
+// paid by senderWallet. The transaction is signed with the hex-encoded signing secret key of the validator.
+// It returns the hash of the transaction.
+func (c *Client) SendDeactivateValidatorTransaction(senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendDeactivateValidatorTransactionContext(context.Background(), senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+}
+
+// SendDeactivateValidatorTransactionContext is like SendDeactivateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendDeactivateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendDeactivateValidatorTransaction", senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateReactivateValidatorTransaction creates a transaction that reactivates the deactivated validator of validatorAddress, paid by senderWallet.
+// The transaction is signed with the hex-encoded signing secret key of the validator.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateReactivateValidatorTransaction(senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateReactivateValidatorTransactionContext(context.Background(), senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+}
+
+// CreateReactivateValidatorTransactionContext is like CreateReactivateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateReactivateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createReactivateValidatorTransaction", senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendReactivateValidatorTransaction sends a transaction that reactivates the deactivated validator of validatorAddress, paid by senderWallet.
+// The transaction is signed with the hex-encoded signing secret key of the validator.
+// It returns the hash of the transaction.
+func (c *Client) SendReactivateValidatorTransaction(senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendReactivateValidatorTransactionContext(context.Background(), senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+}
+
+// SendReactivateValidatorTransactionContext is like SendReactivateValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendReactivateValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorAddress nimiqrpc.Address, signingSecretKey string, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendReactivateValidatorTransaction", senderWallet, validatorAddress, signingSecretKey, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// CreateRetireValidatorTransaction creates a transaction that permanently retires the validator of validatorWallet, paid by senderWallet.
+// It returns the hex-encoded signed transaction, without sending it.
+func (c *Client) CreateRetireValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	return c.CreateRetireValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, fee, validityStartHeight)
+}
+
+// CreateRetireValidatorTransactionContext is like CreateRetireValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) CreateRetireValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHex string, err error) {
+	err = c.callFor(ctx, &transactionHex, nil, "createRetireValidatorTransaction", senderWallet, validatorWallet, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
+
+// SendRetireValidatorTransaction sends a transaction that permanently retires the validator of validatorWallet, paid by senderWallet.
+// It returns the hash of the transaction.
+func (c *Client) SendRetireValidatorTransaction(senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	return c.SendRetireValidatorTransactionContext(context.Background(), senderWallet, validatorWallet, fee, validityStartHeight)
+}
+
+// SendRetireValidatorTransactionContext is like SendRetireValidatorTransaction but uses ctx for cancellation and deadlines.
+func (c *Client) SendRetireValidatorTransactionContext(ctx context.Context, senderWallet nimiqrpc.Address, validatorWallet nimiqrpc.Address, fee nimiqrpc.Luna, validityStartHeight ValidityStartHeight) (transactionHash string, err error) {
+	err = c.callFor(ctx, &transactionHash, nil, "sendRetireValidatorTransaction", senderWallet, validatorWallet, fee, validityStartHeight)
+	if err != nil {
+		return "", err
+	}
+
+	return
+}
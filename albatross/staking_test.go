@@ -0,0 +1,88 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// Addresses used by the tests
+var (
+	validatorAddress = nimiqrpc.MustParseAddress("NQ43 040G 2081 040G 2081 040G 2081 040G 2081")
+	rewardAddress    = nimiqrpc.MustParseAddress("NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2")
+	stakerAddress    = nimiqrpc.MustParseAddress("NQ44 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3")
+	newRewardAddress = nimiqrpc.MustParseAddress("NQ32 0G20 8104 0G20 8104 0G20 8104 0G20 8104")
+)
+
+func TestClientValidators(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"getActiveValidators":   `{"data":[{"address":"NQ43 040G 2081 040G 2081 040G 2081 040G 2081","signingKey":"aa","votingKey":"bb","rewardAddress":"NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2","balance":1000000,"numStakers":2,"retired":false}],"metadata":{"blockNumber":100,"blockHash":"abc"}}`,
+		"getValidatorByAddress": `{"data":{"address":"NQ43 040G 2081 040G 2081 040G 2081 040G 2081","balance":1000000,"numStakers":2,"inactivityFlag":90,"retired":false},"metadata":{"blockNumber":100,"blockHash":"abc"}}`,
+		"getSlotAt":             `{"data":{"slotNumber":12,"validator":"NQ43 040G 2081 040G 2081 040G 2081 040G 2081","publicKey":"bb"},"metadata":null}`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	validators, state, err := client.GetActiveValidators()
+	if err != nil || len(validators) != 1 || validators[0].RewardAddress != rewardAddress || validators[0].Balance != 1000000 || validators[0].NumStakers != 2 {
+		t.Fatalf("GetActiveValidators: got %+v, %v", validators, err)
+	}
+	if state.BlockNumber != 100 {
+		t.Errorf("GetActiveValidators: unexpected metadata %+v", state)
+	}
+
+	validator, _, err := client.GetValidatorByAddress(validatorAddress.String())
+	if err != nil || validator.InactivityFlag == nil || *validator.InactivityFlag != 90 {
+		t.Errorf("GetValidatorByAddress: got %+v, %v", validator, err)
+	}
+
+	slot, err := client.GetSlotAt(100)
+	if err != nil || slot.SlotNumber != 12 || slot.Validator != validatorAddress {
+		t.Errorf("GetSlotAt: got %+v, %v", slot, err)
+	}
+	if params := node.params["getSlotAt"]; params != "[100,null]" {
+		t.Errorf("unexpected params %s", params)
+	}
+}
+
+func TestClientStaking(t *testing.T) {
+	node := newFakeNode(map[string]string{
+		"getStakerByAddress":             `{"data":{"address":"NQ44 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3","balance":5000,"delegation":"NQ43 040G 2081 040G 2081 040G 2081 040G 2081","inactiveBalance":0,"retiredBalance":0},"metadata":{"blockNumber":100,"blockHash":"abc"}}`,
+		"sendNewStakerTransaction":       `{"data":"0123","metadata":null}`,
+		"sendUpdateValidatorTransaction": `{"data":"4567","metadata":null}`,
+	})
+	defer node.Close()
+	client := NewClient(node.URL)
+
+	staker, _, err := client.GetStakerByAddress(stakerAddress.String())
+	if err != nil || staker.Balance != 5000 || staker.Delegation == nil || *staker.Delegation != validatorAddress || staker.InactiveFrom != nil {
+		t.Errorf("GetStakerByAddress: got %+v, %v", staker, err)
+	}
+
+	hash, err := client.SendNewStakerTransaction(stakerAddress, stakerAddress, nil, 5000, 0, RelativeHeight(0))
+	if err != nil || hash != "0123" {
+		t.Fatalf("SendNewStakerTransaction: got %s, %v", hash, err)
+	}
+	if params := node.params["sendNewStakerTransaction"]; params != `["NQ44 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3","NQ44 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3 0C1G 60Q3",null,5000,0,"+0"]` {
+		t.Errorf("unexpected params %s", params)
+	}
+
+	_, err = client.SendUpdateValidatorTransaction(validatorAddress, validatorAddress, "", "", &newRewardAddress, "", 0, AbsoluteHeight(100))
+	if params := node.params["sendUpdateValidatorTransaction"]; err != nil || params != `["NQ43 040G 2081 040G 2081 040G 2081 040G 2081","NQ43 040G 2081 040G 2081 040G 2081 040G 2081",null,null,"NQ32 0G20 8104 0G20 8104 0G20 8104 0G20 8104",null,0,100]` {
+		t.Errorf("unexpected params %s, %v", params, err)
+	}
+}
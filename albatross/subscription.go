@@ -0,0 +1,322 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// ErrSubscriptionsNotSupported is returned when subscribing with a Client that is not connected over WebSocket
+var ErrSubscriptionsNotSupported = errors.New("subscriptions require a WebSocket connection")
+
+// Log types of the logs of blocks and transactions
+const (
+	LogTypePayFee              LogType = "pay-fee"
+	LogTypeTransfer            LogType = "transfer"
+	LogTypeHTLCCreate          LogType = "htlc-create"
+	LogTypeHTLCTimeoutResolve  LogType = "htlc-timeout-resolve"
+	LogTypeHTLCRegularTransfer LogType = "htlc-regular-transfer"
+	LogTypeHTLCEarlyResolve    LogType = "htlc-early-resolve"
+	LogTypeVestingCreate       LogType = "vesting-create"
+	LogTypeCreateValidator     LogType = "create-validator"
+	LogTypeUpdateValidator     LogType = "update-validator"
+	LogTypeDeactivateValidator LogType = "deactivate-validator"
+	LogTypeReactivateValidator LogType = "reactivate-validator"
+	LogTypeRetireValidator     LogType = "retire-validator"
+	LogTypeCreateStaker        LogType = "create-staker"
+	LogTypeStake               LogType = "stake"
+	LogTypeUpdateStaker        LogType = "update-staker"
+	LogTypePayoutReward        LogType = "payout-reward"
+)
+
+// Block log types
+const (
+	BlockLogApplied  = "applied-block"
+	BlockLogReverted = "reverted-block"
+)
+
+// LogType is the type of a log
+type LogType string
+
+// Log is a single event that happened when a block or transaction was applied or reverted.
+// The fields of a log depend on its type, the full log is available as JSON in Data.
+type Log struct {
+	Type LogType         `json:"type"`
+	Data json.RawMessage `json:"-"` // the JSON object of the log
+}
+
+// UnmarshalJSON decodes the type of a log and keeps the full log in Data
+func (l *Log) UnmarshalJSON(data []byte) error {
+	var log struct {
+		Type LogType `json:"type"`
+	}
+	err := json.Unmarshal(data, &log)
+	if err != nil {
+		return err
+	}
+
+	l.Type = log.Type
+	l.Data = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// TransactionLog holds the logs of a transaction
+type TransactionLog struct {
+	Hash     string `json:"hash"`     // hash of the transaction
+	Logs     []Log  `json:"logs"`     // logs of the transaction
+	FailedTx bool   `json:"failedTx"` // whether the execution of the transaction failed
+}
+
+// BlockLog holds the logs of an applied or reverted block
+type BlockLog struct {
+	Type            string           `json:"type"`                // BlockLogApplied or BlockLogReverted
+	BlockHash       string           `json:"blockHash"`           // hash of the block
+	BlockNumber     int              `json:"blockNumber"`         // height of the block
+	Timestamp       int64            `json:"timestamp,omitempty"` // UNIX timestamp of the block in milliseconds, only set for applied blocks
+	InherentLogs    []Log            `json:"inherentLogs"`        // logs of the inherents of the block, like rewards
+	TransactionLogs []TransactionLog `json:"transactionLogs"`     // logs of the transactions of the block
+}
+
+// subscription is the common part of all subscriptions. The notifications of the underlying
+// nimiqrpc.Subscription are decoded and forwarded on a typed channel.
+type subscription struct {
+	sub *nimiqrpc.Subscription
+
+	mu  sync.Mutex
+	err error
+}
+
+// Unsubscribe cancels the subscription at the node and ends it. The subscription ends even if
+// the node returns an error.
+func (s *subscription) Unsubscribe() error {
+	return s.sub.Unsubscribe()
+}
+
+// Err returns the error that ended the subscription, or nil if the subscription is active,
+// was unsubscribed or the client was closed.
+func (s *subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	return s.sub.Err()
+}
+
+// forward calls deliver with the data of every notification until the subscription ends, and calls
+// done afterwards. If the data of a notification cannot be decoded, the subscription is ended.
+func (s *subscription) forward(deliver func(data json.RawMessage, ended <-chan struct{}) error, done func()) {
+	defer done()
+
+	for result := range s.sub.Notifications() {
+		// Notifications carry the same envelope as the results of calls
+		var notification envelope
+		if json.Unmarshal(result, &notification) != nil || len(notification.Data) == 0 {
+			notification.Data = result
+		}
+
+		err := deliver(notification.Data, s.sub.Done())
+		if err != nil {
+			s.mu.Lock()
+			s.err = fmt.Errorf("%w: %v", nimiqrpc.ErrResultUnexpected, err)
+			s.mu.Unlock()
+			s.sub.Unsubscribe()
+			return
+		}
+	}
+}
+
+// subscribe calls the given subscription method
+func (c *Client) subscribe(ctx context.Context, method string, params ...interface{}) (*nimiqrpc.Subscription, error) {
+	if c.ws == nil {
+		return nil, ErrSubscriptionsNotSupported
+	}
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	return c.ws.Subscribe(ctx, method, params)
+}
+
+// BlockSubscription delivers new blocks
+type BlockSubscription struct {
+	subscription
+	blocks chan Block
+}
+
+// Blocks returns the channel on which the blocks are delivered. The channel is closed when the subscription ends.
+func (s *BlockSubscription) Blocks() <-chan Block {
+	return s.blocks
+}
+
+// SubscribeForHeadBlock subscribes to the new head blocks of the chain. If includeBody is true
+// the transactions of the blocks are included.
+func (c *Client) SubscribeForHeadBlock(ctx context.Context, includeBody bool) (*BlockSubscription, error) {
+	sub, err := c.subscribe(ctx, "subscribeForHeadBlock", includeBody)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BlockSubscription{
+		subscription: subscription{sub: sub},
+		blocks:       make(chan Block),
+	}
+	go s.forward(func(data json.RawMessage, ended <-chan struct{}) error {
+		var block Block
+		err := json.Unmarshal(data, &block)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.blocks <- block:
+		case <-ended:
+		}
+		return nil
+	}, func() { close(s.blocks) })
+
+	return s, nil
+}
+
+// HashSubscription delivers hashes
+type HashSubscription struct {
+	subscription
+	hashes chan string
+}
+
+// Hashes returns the channel on which the hashes are delivered. The channel is closed when the subscription ends.
+func (s *HashSubscription) Hashes() <-chan string {
+	return s.hashes
+}
+
+// SubscribeForHeadBlockHash subscribes to the hashes of the new head blocks of the chain
+func (c *Client) SubscribeForHeadBlockHash(ctx context.Context) (*HashSubscription, error) {
+	sub, err := c.subscribe(ctx, "subscribeForHeadBlockHash")
+	if err != nil {
+		return nil, err
+	}
+
+	s := &HashSubscription{
+		subscription: subscription{sub: sub},
+		hashes:       make(chan string),
+	}
+	go s.forward(func(data json.RawMessage, ended <-chan struct{}) error {
+		var hash string
+		err := json.Unmarshal(data, &hash)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.hashes <- hash:
+		case <-ended:
+		}
+		return nil
+	}, func() { close(s.hashes) })
+
+	return s, nil
+}
+
+// ValidatorSubscription delivers the details on a validator
+type ValidatorSubscription struct {
+	subscription
+	validators chan Validator
+}
+
+// Validators returns the channel on which the validators are delivered. The channel is closed when
+// the subscription ends.
+func (s *ValidatorSubscription) Validators() <-chan Validator {
+	return s.validators
+}
+
+// SubscribeForValidatorElectionByAddress subscribes to the elections of the validator of the given address.
+// The details on the validator are delivered at every election block in which the validator is elected.
+func (c *Client) SubscribeForValidatorElectionByAddress(ctx context.Context, address nimiqrpc.Address) (*ValidatorSubscription, error) {
+	sub, err := c.subscribe(ctx, "subscribeForValidatorElectionByAddress", address)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &ValidatorSubscription{
+		subscription: subscription{sub: sub},
+		validators:   make(chan Validator),
+	}
+	go s.forward(func(data json.RawMessage, ended <-chan struct{}) error {
+		var validator Validator
+		err := json.Unmarshal(data, &validator)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.validators <- validator:
+		case <-ended:
+		}
+		return nil
+	}, func() { close(s.validators) })
+
+	return s, nil
+}
+
+// LogSubscription delivers block logs
+type LogSubscription struct {
+	subscription
+	logs chan BlockLog
+}
+
+// Logs returns the channel on which the block logs are delivered. The channel is closed when the
+// subscription ends.
+func (s *LogSubscription) Logs() <-chan BlockLog {
+	return s.logs
+}
+
+// SubscribeForLogsByAddressesAndTypes subscribes to the logs of applied and reverted blocks that affect
+// the given addresses and have one of the given types. Empty addresses or log types match all.
+func (c *Client) SubscribeForLogsByAddressesAndTypes(ctx context.Context, addresses []nimiqrpc.Address, logTypes []LogType) (*LogSubscription, error) {
+	if addresses == nil {
+		addresses = []nimiqrpc.Address{}
+	}
+	if logTypes == nil {
+		logTypes = []LogType{}
+	}
+
+	sub, err := c.subscribe(ctx, "subscribeForLogsByAddressesAndTypes", addresses, logTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &LogSubscription{
+		subscription: subscription{sub: sub},
+		logs:         make(chan BlockLog),
+	}
+	go s.forward(func(data json.RawMessage, ended <-chan struct{}) error {
+		var log BlockLog
+		err := json.Unmarshal(data, &log)
+		if err != nil {
+			return err
+		}
+		select {
+		case s.logs <- log:
+		case <-ended:
+		}
+		return nil
+	}, func() { close(s.logs) })
+
+	return s, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// wsNode is a test WebSocket server that accepts every subscribe call and pushes the
+// given notification results to the subscription right after subscribing
+type wsNode struct {
+	*httptest.Server
+	params map[string]string // params of the subscribe calls by method
+}
+
+func newWSNode(notifications map[string][]string) *wsNode {
+	node := &wsNode{
+		params: make(map[string]string),
+	}
+	upgrader := websocket.Upgrader{}
+	node.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		for id := 1; ; id++ {
+			var req struct {
+				ID     int             `json:"id"`
+				Method string          `json:"method"`
+				Params json.RawMessage `json:"params"`
+			}
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			node.params[req.Method] = string(req.Params)
+
+			conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": id})
+			for _, result := range notifications[req.Method] {
+				conn.WriteMessage(websocket.TextMessage, []byte(`{"jsonrpc":"2.0","method":"`+req.Method+
+					`","params":{"subscription":`+itoa(id)+`,"result":`+result+`}}`))
+			}
+		}
+	}))
+	return node
+}
+
+func itoa(i int) string {
+	data, _ := json.Marshal(i)
+	return string(data)
+}
+
+func TestClientSubscriptions(t *testing.T) {
+	node := newWSNode(map[string][]string{
+		"subscribeForHeadBlockHash": {`{"data":"abc","metadata":null}`, `{"data":"def","metadata":null}`},
+		"subscribeForLogsByAddressesAndTypes": {`{"data":{"type":"applied-block","blockHash":"abc","blockNumber":12,"timestamp":1000,"inherentLogs":[],` +
+			`"transactionLogs":[{"hash":"123","logs":[{"type":"transfer","from":"NQ01","to":"NQ02","amount":100}],"failedTx":false}]},"metadata":null}`},
+		"subscribeForHeadBlock": {`{"data":"not a block","metadata":null}`},
+	})
+	defer node.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	client, err := DialWebSocket(ctx, "ws"+strings.TrimPrefix(node.URL, "http"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	hashes, err := client.SubscribeForHeadBlockHash(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{"abc", "def"} {
+		if hash := <-hashes.Hashes(); hash != expected {
+			t.Errorf("expected hash %s, got %s", expected, hash)
+		}
+	}
+	if err := hashes.Unsubscribe(); err != nil {
+		t.Errorf("Unsubscribe: %v", err)
+	}
+	if node.params["unsubscribe"] != "[1]" {
+		t.Errorf("expected unsubscribe of subscription 1, got params %s", node.params["unsubscribe"])
+	}
+	if _, ok := <-hashes.Hashes(); ok {
+		t.Error("expected hashes channel to be closed after Unsubscribe")
+	}
+
+	logs, err := client.SubscribeForLogsByAddressesAndTypes(ctx, []nimiqrpc.Address{rewardAddress}, []LogType{LogTypeTransfer})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if node.params["subscribeForLogsByAddressesAndTypes"] != `[["NQ23 0810 40G2 0810 40G2 0810 40G2 0810 40G2"],["transfer"]]` {
+		t.Errorf("unexpected params %s", node.params["subscribeForLogsByAddressesAndTypes"])
+	}
+	log := <-logs.Logs()
+	if log.Type != BlockLogApplied || log.BlockNumber != 12 || len(log.TransactionLogs) != 1 {
+		t.Fatalf("unexpected block log %+v", log)
+	}
+	if trnLog := log.TransactionLogs[0]; trnLog.Hash != "123" || len(trnLog.Logs) != 1 || trnLog.Logs[0].Type != LogTypeTransfer {
+		t.Errorf("unexpected transaction log %+v", trnLog)
+	}
+	var transfer struct {
+		Amount int `json:"amount"`
+	}
+	if err := json.Unmarshal(log.TransactionLogs[0].Logs[0].Data, &transfer); err != nil || transfer.Amount != 100 {
+		t.Errorf("unexpected log data %s", log.TransactionLogs[0].Logs[0].Data)
+	}
+	logs.Unsubscribe()
+
+	blocks, err := client.SubscribeForHeadBlock(ctx, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := <-blocks.Blocks(); ok {
+		t.Error("expected blocks channel to be closed after undecodable notification")
+	}
+	if blocks.Err() == nil {
+		t.Error("expected error after undecodable notification")
+	}
+}
+
+func TestClientSubscriptionsNotSupported(t *testing.T) {
+	client := NewClient("http://127.0.0.1:0")
+	if _, err := client.SubscribeForHeadBlockHash(context.Background()); !errors.Is(err, ErrSubscriptionsNotSupported) {
+		t.Errorf("expected ErrSubscriptionsNotSupported, got %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package albatross
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// Account types on the Albatross chain
+const (
+	AccountTypeBasic   AccountType = "basic"
+	AccountTypeVesting AccountType = "vesting"
+	AccountTypeHTLC    AccountType = "htlc"
+	AccountTypeStaking AccountType = "staking"
+)
+
+// Block types on the Albatross chain
+const (
+	BlockTypeMicro BlockType = "micro"
+	BlockTypeMacro BlockType = "macro"
+)
+
+// AccountType is the type of an account on the Albatross chain
+type AccountType string
+
+// BlockType is the type of a block on the Albatross chain
+type BlockType string
+
+// Account holds the details on an account
+type Account struct {
+	Address nimiqrpc.Address `json:"address"` // address of the account
+	Balance nimiqrpc.Luna    `json:"balance"` // balance of the account in Luna
+	Type    AccountType      `json:"type"`    // see AccountType const block
+
+	// Additional fields for AccountTypeVesting
+	Owner              nimiqrpc.Address `json:"owner,omitempty"`              // address of the contract owner
+	VestingStartTime   int64            `json:"vestingStartTime,omitempty"`   // UNIX timestamp in milliseconds at which the vesting commenced
+	VestingTimeStep    int64            `json:"vestingTimeStep,omitempty"`    // milliseconds after which some part of the vested funds is released
+	VestingStepAmount  nimiqrpc.Luna    `json:"vestingStepAmount,omitempty"`  // amount in Luna released every VestingTimeStep
+	VestingTotalAmount nimiqrpc.Luna    `json:"vestingTotalAmount,omitempty"` // total amount in Luna that was provided at the contract creation
+
+	// Additional fields for AccountTypeHTLC
+	Sender      nimiqrpc.Address `json:"sender,omitempty"`      // address of the HTLC sender
+	Recipient   nimiqrpc.Address `json:"recipient,omitempty"`   // address of the HTLC recipient
+	HashRoot    string           `json:"hashRoot,omitempty"`    // hex-encoded hash root
+	HashCount   int              `json:"hashCount,omitempty"`   // no. of hashes this HTLC is split into
+	Timeout     int64            `json:"timeout,omitempty"`     // UNIX timestamp in milliseconds at which the HTLC times out
+	TotalAmount nimiqrpc.Luna    `json:"totalAmount,omitempty"` // total amount in Luna provided at contract creation
+}
+
+// Block holds the details on a block
+type Block struct {
+	Hash        string    `json:"hash"`        // block hash
+	Size        int       `json:"size"`        // block size in bytes
+	Batch       int       `json:"batch"`       // number of the batch of the block
+	Epoch       int       `json:"epoch"`       // number of the epoch of the block
+	Network     string    `json:"network"`     // network the block belongs to
+	Version     int       `json:"version"`     // block version
+	Number      int       `json:"number"`      // height of the block
+	Timestamp   int64     `json:"timestamp"`   // UNIX timestamp of the block in milliseconds
+	ParentHash  string    `json:"parentHash"`  // hash of the predecessor block
+	Seed        string    `json:"seed"`        // hex-encoded VRF seed of the block
+	ExtraData   string    `json:"extraData"`   // hex-encoded value of the extra data field
+	StateHash   string    `json:"stateHash"`   // hash of the accounts tree root
+	BodyHash    string    `json:"bodyHash"`    // hash of the block body
+	HistoryHash string    `json:"historyHash"` // hash of the history tree root
+	Type        BlockType `json:"type"`        // see BlockType const block
+
+	// Additional fields for BlockTypeMicro
+	Producer *Slot `json:"producer,omitempty"` // slot of the validator that produced the block
+
+	// Additional fields for BlockTypeMacro
+	IsElectionBlock    bool   `json:"isElectionBlock,omitempty"`    // whether the block is an election block
+	ParentElectionHash string `json:"parentElectionHash,omitempty"` // hash of the preceding election block
+
+	// Transactions is only set if the block was requested with its body
+	Transactions []Transaction `json:"transactions,omitempty"`
+}
+
+// BlockchainState holds the state of the chain at which a result was obtained
+type BlockchainState struct {
+	BlockNumber int    `json:"blockNumber"` // height of the head block
+	BlockHash   string `json:"blockHash"`   // hash of the head block
+}
+
+// Slot holds the details on the validator of a slot
+type Slot struct {
+	SlotNumber int              `json:"slotNumber"` // number of the slot
+	Validator  nimiqrpc.Address `json:"validator"`  // address of the validator
+	PublicKey  string           `json:"publicKey"`  // hex-encoded BLS public key of the validator
+}
+
+// Staker holds the details on a staker
+type Staker struct {
+	Address         nimiqrpc.Address  `json:"address"`                // address of the staker
+	Balance         nimiqrpc.Luna     `json:"balance"`                // active stake in Luna
+	Delegation      *nimiqrpc.Address `json:"delegation,omitempty"`   // address of the validator the stake is delegated to, nil if the stake is not delegated
+	InactiveBalance nimiqrpc.Luna     `json:"inactiveBalance"`        // inactive stake in Luna
+	InactiveFrom    *int              `json:"inactiveFrom,omitempty"` // block from which on the stake is inactive, nil if there is no inactive stake
+	RetiredBalance  nimiqrpc.Luna     `json:"retiredBalance"`         // retired stake in Luna, which can be removed
+}
+
+// Transaction holds the details on a transaction
+type Transaction struct {
+	Hash             string             `json:"hash"`                       // hash of the transaction
+	BlockNumber      int                `json:"blockNumber,omitempty"`      // number of the containing block (0 if not mined)
+	Timestamp        int64              `json:"timestamp,omitempty"`        // UNIX timestamp of the containing block in milliseconds
+	Confirmations    int                `json:"confirmations,omitempty"`    // number of blocks since the transaction was mined
+	Size             int                `json:"size"`                       // transaction size in bytes
+	RelatedAddresses []nimiqrpc.Address `json:"relatedAddresses,omitempty"` // addresses affected by the transaction
+
+	From     nimiqrpc.Address `json:"from"`     // address of the sending account
+	FromType AccountType      `json:"fromType"` // account type of the sending account
+	To       nimiqrpc.Address `json:"to"`       // address of the recipient account
+	ToType   AccountType      `json:"toType"`   // account type of the recipient account
+
+	Value               nimiqrpc.Luna `json:"value"`
+	Fee                 nimiqrpc.Luna `json:"fee"`
+	SenderData          string        `json:"senderData"`                // hex-encoded data for the sending account
+	RecipientData       string        `json:"recipientData"`             // hex-encoded contract parameters or a message
+	Flags               int           `json:"flags"`                     // bit-encoded transaction flags
+	ValidityStartHeight int           `json:"validityStartHeight"`       // block from which on the transaction is valid
+	Proof               string        `json:"proof"`                     // hex-encoded proof of the transaction
+	NetworkID           int           `json:"networkId"`                 // ID of the network the transaction belongs to
+	ExecutionResult     *bool         `json:"executionResult,omitempty"` // whether the transaction was executed successfully, nil if not mined
+}
+
+// ValidityStartHeight is the height from which on a new transaction is valid. It is either absolute,
+// or relative to the head of the chain of the node. The zero value is relative to the current head.
+type ValidityStartHeight struct {
+	height   uint32
+	absolute bool
+}
+
+// AbsoluteHeight returns a ValidityStartHeight at the given block height
+func AbsoluteHeight(height uint32) ValidityStartHeight {
+	return ValidityStartHeight{
+		height:   height,
+		absolute: true,
+	}
+}
+
+// RelativeHeight returns a ValidityStartHeight the given number of blocks after the current head
+func RelativeHeight(blocks uint32) ValidityStartHeight {
+	return ValidityStartHeight{
+		height: blocks,
+	}
+}
+
+// String returns the validity start height as a block height, or as "+n" if it is relative
+func (vsh ValidityStartHeight) String() string {
+	if vsh.absolute {
+		return strconv.FormatUint(uint64(vsh.height), 10)
+	}
+	return "+" + strconv.FormatUint(uint64(vsh.height), 10)
+}
+
+// MarshalJSON encodes an absolute validity start height as a number, and a relative
+// validity start height as a "+n" string.
+func (vsh ValidityStartHeight) MarshalJSON() ([]byte, error) {
+	if vsh.absolute {
+		return json.Marshal(vsh.height)
+	}
+	return json.Marshal(vsh.String())
+}
+
+// Validator holds the details on a validator
+type Validator struct {
+	Address        nimiqrpc.Address `json:"address"`                  // address of the validator
+	SigningKey     string           `json:"signingKey"`               // hex-encoded Schnorr public key used to sign blocks
+	VotingKey      string           `json:"votingKey"`                // hex-encoded BLS public key used to vote on blocks
+	RewardAddress  nimiqrpc.Address `json:"rewardAddress"`            // address the rewards are paid to
+	SignalData     string           `json:"signalData,omitempty"`     // hex-encoded signal data
+	Balance        nimiqrpc.Luna    `json:"balance"`                  // total stake of the validator in Luna, including delegated stake
+	NumStakers     int              `json:"numStakers"`               // no. of stakers delegating their stake to the validator
+	InactivityFlag *int             `json:"inactivityFlag,omitempty"` // block from which on the validator is inactive, nil if active
+	Retired        bool             `json:"retired"`                  // whether the validator is retired
+}
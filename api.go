@@ -15,20 +15,142 @@
 package nimiqrpc
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+
+	"github.com/ybbus/jsonrpc"
 )
 
-// Accounts returns a list of addresses owned by client.
-func (nc *Client) Accounts() (accounts []Account, err error) {
-	rpcResp, err := nc.Call("accounts", nil)
+// callFor does an RPC call and decodes the result into out. JSON-RPC errors returned by the node are
+// returned as *RPCError.
+func (nc *Client) callFor(ctx context.Context, out interface{}, method string, params interface{}) error {
+	rpcResp, err := nc.CallContext(ctx, method, params)
+	if err != nil {
+		return err
+	}
+
+	return decodeResult(method, rpcResp, out)
+}
+
+// decodeResult decodes the result of a JSON-RPC response into out. If out is nil, only
+// the JSON-RPC error of the response is checked.
+func decodeResult(method string, rpcResp *jsonrpc.RPCResponse, out interface{}) error {
+	if rpcResp.Error != nil {
+		return newRPCError(method, rpcResp.Error)
+	}
+	if out == nil {
+		return nil
+	}
+
+	err := rpcResp.GetObject(out)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrResultUnexpected, err)
+	}
+
+	return nil
+}
+
+// decodeBlock decodes a block result. If fullTransactions is true the transactions of the block
+// are decoded as transaction objects, otherwise as transaction hashes. A null result is returned as nil.
+func decodeBlock(method string, rpcResp *jsonrpc.RPCResponse, fullTransactions bool) (*Block, error) {
+	var result Block
+	err := decodeResult(method, rpcResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Hash == "" {
+		return nil, nil
+	}
+
+	// Transaction result
+	switch {
+	case fullTransactions:
+		err = json.Unmarshal(result.Transactions, &result.TransactionObjects)
+	default:
+		err = json.Unmarshal(result.Transactions, &result.TransactionHashes)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
+	}
+
+	return &result, nil
+}
+
+// decodeTransaction decodes a transaction result. A null result is returned as nil.
+func decodeTransaction(method string, rpcResp *jsonrpc.RPCResponse) (*Transaction, error) {
+	var result Transaction
+	err := decodeResult(method, rpcResp, &result)
 	if err != nil {
 		return nil, err
 	}
 
-	err = rpcResp.GetObject(&accounts)
+	if result.Hash == "" {
+		return nil, nil
+	}
+
+	return &result, nil
+}
+
+// decodeTransactionReceipt decodes a transaction receipt result. A null result is returned as nil.
+func decodeTransactionReceipt(method string, rpcResp *jsonrpc.RPCResponse) (*TransactionReceipt, error) {
+	var result TransactionReceipt
+	err := decodeResult(method, rpcResp, &result)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
+	}
+
+	if result.TransactionHash == "" {
+		return nil, nil
+	}
+
+	return &result, nil
+}
+
+// decodeMempoolContent decodes a mempoolContent result. If includeTransactions is true the result
+// is decoded as transaction objects, otherwise as transaction hashes.
+func decodeMempoolContent(method string, rpcResp *jsonrpc.RPCResponse, includeTransactions bool) (*MempoolContent, error) {
+	var result MempoolContent
+	var err error
+	switch {
+	case includeTransactions:
+		err = decodeResult(method, rpcResp, &result.TransactionObjects)
+	default:
+		err = decodeResult(method, rpcResp, &result.TransactionHashes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// decodeMempool decodes a mempool result. An empty mempool is returned as nil.
+func decodeMempool(method string, rpcResp *jsonrpc.RPCResponse) (*Mempool, error) {
+	var result Mempool
+	err := decodeResult(method, rpcResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Total == 0 && len(result.Buckets) == 0 {
+		return nil, nil
+	}
+
+	return &result, nil
+}
+
+// Accounts returns a list of addresses owned by client.
+func (nc *Client) Accounts() (accounts []Account, err error) {
+	return nc.AccountsContext(context.Background())
+}
+
+// AccountsContext is like Accounts but uses ctx for cancellation and deadlines.
+func (nc *Client) AccountsContext(ctx context.Context) (accounts []Account, err error) {
+	err = nc.callFor(ctx, &accounts, "accounts", nil)
+	if err != nil {
+		return nil, err
 	}
 
 	return
@@ -36,14 +158,14 @@ func (nc *Client) Accounts() (accounts []Account, err error) {
 
 // BlockNumber returns the height of most recent block.
 func (nc *Client) BlockNumber() (blockHeight int, err error) {
-	rpcResp, err := nc.Call("blockNumber", nil)
-	if err != nil {
-		return 0, err
-	}
+	return nc.BlockNumberContext(context.Background())
+}
 
-	err = rpcResp.GetObject(&blockHeight)
+// BlockNumberContext is like BlockNumber but uses ctx for cancellation and deadlines.
+func (nc *Client) BlockNumberContext(ctx context.Context) (blockHeight int, err error) {
+	err = nc.callFor(ctx, &blockHeight, "blockNumber", nil)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
 
 	return
@@ -51,14 +173,29 @@ func (nc *Client) BlockNumber() (blockHeight int, err error) {
 
 // Consensus returns information on the current consensus state.
 func (nc *Client) Consensus() (consensus string, err error) {
-	rpcResp, err := nc.Call("consensus", nil)
+	return nc.ConsensusContext(context.Background())
+}
+
+// ConsensusContext is like Consensus but uses ctx for cancellation and deadlines.
+func (nc *Client) ConsensusContext(ctx context.Context) (consensus string, err error) {
+	err = nc.callFor(ctx, &consensus, "consensus", nil)
 	if err != nil {
 		return "", err
 	}
 
-	err = rpcResp.GetObject(&consensus)
+	return
+}
+
+// Constant returns the value of the constant with the given name.
+func (nc *Client) Constant(constant string) (value int, err error) {
+	return nc.ConstantContext(context.Background(), constant)
+}
+
+// ConstantContext is like Constant but uses ctx for cancellation and deadlines.
+func (nc *Client) ConstantContext(ctx context.Context, constant string) (value int, err error) {
+	err = nc.callFor(ctx, &value, "constant", constant)
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
 
 	return
@@ -66,16 +203,15 @@ func (nc *Client) Consensus() (consensus string, err error) {
 
 // CreateAccount creates a new account and stores its private key in the client store.
 func (nc *Client) CreateAccount() (wallet *Wallet, err error) {
-	rpcResp, err := nc.Call("createAccount", nil)
-	if err != nil {
-		return nil, err
-	}
+	return nc.CreateAccountContext(context.Background())
+}
 
-	// Unmarshal result
+// CreateAccountContext is like CreateAccount but uses ctx for cancellation and deadlines.
+func (nc *Client) CreateAccountContext(ctx context.Context) (wallet *Wallet, err error) {
 	var result Wallet
-	err = rpcResp.GetObject(&result)
+	err = nc.callFor(ctx, &result, "createAccount", nil)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
 	return &result, nil
@@ -84,12 +220,16 @@ func (nc *Client) CreateAccount() (wallet *Wallet, err error) {
 // CreateRawTransaction creates and signs a transaction without sending it.
 // The transaction can then be send via sendRawTransaction without accidentally replaying it.
 func (nc *Client) CreateRawTransaction(trn OutgoingTransaction) (transactionHex string, err error) {
-	rpcResp, err := nc.Call("createRawTransaction", trn)
+	return nc.CreateRawTransactionContext(context.Background(), trn)
+}
+
+// CreateRawTransactionContext is like CreateRawTransaction but uses ctx for cancellation and deadlines.
+func (nc *Client) CreateRawTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHex string, err error) {
+	err = checkRemoteTransaction(trn)
 	if err != nil {
 		return "", err
 	}
-
-	err = rpcResp.GetObject(&transactionHex)
+	err = nc.callFor(ctx, &transactionHex, "createRawTransaction", trn)
 	if err != nil {
 		return "", err
 	}
@@ -97,33 +237,57 @@ func (nc *Client) CreateRawTransaction(trn OutgoingTransaction) (transactionHex
 	return
 }
 
+// DisconnectPool disconnects the miner from its mining pool.
+func (nc *Client) DisconnectPool() (err error) {
+	return nc.DisconnectPoolContext(context.Background())
+}
+
+// DisconnectPoolContext is like DisconnectPool but uses ctx for cancellation and deadlines.
+func (nc *Client) DisconnectPoolContext(ctx context.Context) (err error) {
+	return nc.callFor(ctx, nil, "pool", false)
+}
+
 // GetAccount returns details for the account of given address.
 func (nc *Client) GetAccount(address string) (account *Account, err error) {
-	rpcResp, err := nc.Call("getAccount", address)
+	return nc.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext is like GetAccount but uses ctx for cancellation and deadlines.
+func (nc *Client) GetAccountContext(ctx context.Context, address string) (account *Account, err error) {
+	var result Account
+	err = nc.callFor(ctx, &result, "getAccount", address)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Account
-	err = rpcResp.GetObject(&result)
+	return &result, nil
+}
+
+// GetTypedAccount returns the account of given address decoded according to its type, see TypedAccount.
+func (nc *Client) GetTypedAccount(address string) (account TypedAccount, err error) {
+	return nc.GetTypedAccountContext(context.Background(), address)
+}
+
+// GetTypedAccountContext is like GetTypedAccount but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTypedAccountContext(ctx context.Context, address string) (account TypedAccount, err error) {
+	result, err := nc.GetAccountContext(ctx, address)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
-	return &result, nil
+	return result.Typed(), nil
 }
 
 // GetBalance returns the balance of the account of given address.
 func (nc *Client) GetBalance(address string) (balance Luna, err error) {
-	rpcResp, err := nc.Call("getBalance", address)
-	if err != nil {
-		return 0, err
-	}
+	return nc.GetBalanceContext(context.Background(), address)
+}
 
-	err = rpcResp.GetObject(&balance)
+// GetBalanceContext is like GetBalance but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBalanceContext(ctx context.Context, address string) (balance Luna, err error) {
+	err = nc.callFor(ctx, &balance, "getBalance", address)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
 
 	return
@@ -133,72 +297,38 @@ func (nc *Client) GetBalance(address string) (balance Luna, err error) {
 // If fullTransactions is true it returns a block with the full transaction objects,
 // if false only the hashes of the transactions will be returned.
 func (nc *Client) GetBlockByHash(blockHash string, fullTransactions bool) (block *Block, err error) {
-	rpcResp, err := nc.Call("getBlockByNumber", []interface{}{
+	return nc.GetBlockByHashContext(context.Background(), blockHash, fullTransactions)
+}
+
+// GetBlockByHashContext is like GetBlockByHash but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *Block, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockByHash", []interface{}{
 		blockHash, fullTransactions,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Block
-	err = rpcResp.GetObject(&result)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Hash == "" {
-		return nil, nil
-	}
-
-	// Transaction result
-	switch {
-	case fullTransactions:
-		err = json.Unmarshal(result.Transactions, &result.TransactionObjects)
-	default:
-		err = json.Unmarshal(result.Transactions, &result.TransactionHashes)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	return &result, nil
+	return decodeBlock("getBlockByHash", rpcResp, fullTransactions)
 }
 
 // GetBlockByNumber returns information about a block by block number.
 // If fullTransactions is true it returns a block with the full transaction objects,
 // if false only the hashes of the transactions will be returned.
 func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (block *Block, err error) {
-	rpcResp, err := nc.Call("getBlockByNumber", []interface{}{
+	return nc.GetBlockByNumberContext(context.Background(), blockNumber, fullTransactions)
+}
+
+// GetBlockByNumberContext is like GetBlockByNumber but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *Block, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getBlockByNumber", []interface{}{
 		blockNumber, fullTransactions,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Block
-	err = rpcResp.GetObject(&result)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Hash == "" {
-		return nil, nil
-	}
-
-	// Transaction result
-	switch {
-	case fullTransactions:
-		err = json.Unmarshal(result.Transactions, &result.TransactionObjects)
-	default:
-		err = json.Unmarshal(result.Transactions, &result.TransactionHashes)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	return &result, nil
+	return decodeBlock("getBlockByNumber", rpcResp, fullTransactions)
 }
 
 // GetBlockTemplate returns a template to build the next block for mining.
@@ -208,16 +338,15 @@ func (nc *Client) GetBlockByNumber(blockNumber int, fullTransactions bool) (bloc
 // and (2)  Hex-encoded value for the extra data field. This overrides the address
 // provided during startup or from the pool.
 func (nc *Client) GetBlockTemplate(params ...interface{}) (template *BlockTemplate, err error) {
-	rpcResp, err := nc.Call("getBlockTemplate", params)
-	if err != nil {
-		return nil, err
-	}
+	return nc.GetBlockTemplateContext(context.Background(), params...)
+}
 
-	// Unmarshal result
+// GetBlockTemplateContext is like GetBlockTemplate but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *BlockTemplate, err error) {
 	var result BlockTemplate
-	err = rpcResp.GetObject(&result)
+	err = nc.callFor(ctx, &result, "getBlockTemplate", params)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
 	return &result, nil
@@ -225,14 +354,14 @@ func (nc *Client) GetBlockTemplate(params ...interface{}) (template *BlockTempla
 
 // GetBlockTransactionCountByHash returns the number of transactions in a block from a block matching the given block hash.
 func (nc *Client) GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error) {
-	rpcResp, err := nc.Call("getBlockTransactionCountByHash", blockHash)
-	if err != nil {
-		return 0, err
-	}
+	return nc.GetBlockTransactionCountByHashContext(context.Background(), blockHash)
+}
 
-	err = rpcResp.GetObject(&transactionCount)
+// GetBlockTransactionCountByHashContext is like GetBlockTransactionCountByHash but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error) {
+	err = nc.callFor(ctx, &transactionCount, "getBlockTransactionCountByHash", blockHash)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
 
 	return
@@ -240,123 +369,116 @@ func (nc *Client) GetBlockTransactionCountByHash(blockHash string) (transactionC
 
 // GetBlockTransactionCountByNumber returns the number of transactions in a block from a block matching the given block number.
 func (nc *Client) GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error) {
-	rpcResp, err := nc.Call("getBlockTransactionCountByNumber", blockNumber)
+	return nc.GetBlockTransactionCountByNumberContext(context.Background(), blockNumber)
+}
+
+// GetBlockTransactionCountByNumberContext is like GetBlockTransactionCountByNumber but uses ctx for cancellation and deadlines.
+func (nc *Client) GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error) {
+	err = nc.callFor(ctx, &transactionCount, "getBlockTransactionCountByNumber", blockNumber)
 	if err != nil {
 		return 0, err
 	}
 
-	err = rpcResp.GetObject(&transactionCount)
+	return
+}
+
+// GetRawTransactionInfo deserializes the hex-encoded transaction and returns information about it,
+// including whether it is valid and whether it is in the mempool.
+func (nc *Client) GetRawTransactionInfo(transactionHex string) (info *RawTransactionInfo, err error) {
+	return nc.GetRawTransactionInfoContext(context.Background(), transactionHex)
+}
+
+// GetRawTransactionInfoContext is like GetRawTransactionInfo but uses ctx for cancellation and deadlines.
+func (nc *Client) GetRawTransactionInfoContext(ctx context.Context, transactionHex string) (info *RawTransactionInfo, err error) {
+	var result RawTransactionInfo
+	err = nc.callFor(ctx, &result, "getRawTransactionInfo", transactionHex)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
-	return
+	return &result, nil
 }
 
 // GetTransactionByBlockHashAndIndex returns information about a transaction by block hash and transaction index position.
 func (nc *Client) GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByBlockHashAndIndex", []interface{}{
+	return nc.GetTransactionByBlockHashAndIndexContext(context.Background(), blockHash, index)
+}
+
+// GetTransactionByBlockHashAndIndexContext is like GetTransactionByBlockHashAndIndex but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByBlockHashAndIndex", []interface{}{
 		blockHash, index,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Transaction
-	err = rpcResp.GetObject(&result)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Hash == "" {
-		return nil, nil
-	}
-
-	return &result, nil
+	return decodeTransaction("getTransactionByBlockHashAndIndex", rpcResp)
 }
 
 // GetTransactionByBlockNumberAndIndex returns information about a transaction by block hash and transaction index position.
 func (nc *Client) GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByBlockNumberAndIndex", []interface{}{
+	return nc.GetTransactionByBlockNumberAndIndexContext(context.Background(), blockNumber, index)
+}
+
+// GetTransactionByBlockNumberAndIndexContext is like GetTransactionByBlockNumberAndIndex but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByBlockNumberAndIndex", []interface{}{
 		blockNumber, index,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Transaction
-	err = rpcResp.GetObject(&result)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Hash == "" {
-		return nil, nil
-	}
-
-	return &result, nil
+	return decodeTransaction("getTransactionByBlockNumberAndIndex", rpcResp)
 }
 
 // GetTransactionByHash Returns the information about a transaction requested by transaction hash.
 func (nc *Client) GetTransactionByHash(transactionHash string) (transaction *Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionByHash", transactionHash)
-	if err != nil {
-		return nil, err
-	}
+	return nc.GetTransactionByHashContext(context.Background(), transactionHash)
+}
 
-	// Unmarshal result
-	var result Transaction
-	err = rpcResp.GetObject(&result)
+// GetTransactionByHashContext is like GetTransactionByHash but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *Transaction, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionByHash", transactionHash)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.Hash == "" {
-		return nil, nil
+		return nil, err
 	}
 
-	return &result, nil
+	return decodeTransaction("getTransactionByHash", rpcResp)
 }
 
 // GetTransactionReceipt returns the receipt of a transaction by transaction hash.
 func (nc *Client) GetTransactionReceipt(transactionHash string) (transactionReceipt *TransactionReceipt, err error) {
-	rpcResp, err := nc.Call("getTransactionReceipt", transactionHash)
-	if err != nil {
-		return nil, err
-	}
+	return nc.GetTransactionReceiptContext(context.Background(), transactionHash)
+}
 
-	// Unmarshal result
-	var result TransactionReceipt
-	err = rpcResp.GetObject(&result)
+// GetTransactionReceiptContext is like GetTransactionReceipt but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *TransactionReceipt, err error) {
+	rpcResp, err := nc.CallContext(ctx, "getTransactionReceipt", transactionHash)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
-	if result.TransactionHash == "" {
-		return nil, nil
+		return nil, err
 	}
 
-	return &result, nil
+	return decodeTransactionReceipt("getTransactionReceipt", rpcResp)
 }
 
 // GetTransactionsByAddress returns the latest transactions successfully performed by or for an address.
 // The array will not contain more than maxEntries, but might contain less, even when more transactions happened.
 // Any interpretation of the length of this array might result in worng assumptions.
 func (nc *Client) GetTransactionsByAddress(address string, maxEntries int) (transactions []Transaction, err error) {
-	rpcResp, err := nc.Call("getTransactionsByAddress", []interface{}{
+	return nc.GetTransactionsByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionsByAddressContext is like GetTransactionsByAddress but uses ctx for cancellation and deadlines.
+func (nc *Client) GetTransactionsByAddressContext(ctx context.Context, address string, maxEntries int) (transactions []Transaction, err error) {
+	err = nc.callFor(ctx, &transactions, "getTransactionsByAddress", []interface{}{
 		address, maxEntries,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	err = rpcResp.GetObject(&transactions)
-	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
 	return
 }
 
@@ -364,16 +486,15 @@ func (nc *Client) GetTransactionsByAddress(address string, maxEntries int) (tran
 // Optional arameters: (1)  The address to use as a miner for this block. This overrides the address provided during startup or from the pool.
 // and (2) Hex-encoded value for the extra data field. This overrides the address provided during startup or from the pool
 func (nc *Client) GetWork(params ...interface{}) (work *Work, err error) {
-	rpcResp, err := nc.Call("getWork", params)
-	if err != nil {
-		return nil, err
-	}
+	return nc.GetWorkContext(context.Background(), params...)
+}
 
-	// Unmarshal result
+// GetWorkContext is like GetWork but uses ctx for cancellation and deadlines.
+func (nc *Client) GetWorkContext(ctx context.Context, params ...interface{}) (work *Work, err error) {
 	var result Work
-	err = rpcResp.GetObject(&result)
+	err = nc.callFor(ctx, &result, "getWork", params)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
 	if result.Data == "" {
@@ -385,14 +506,14 @@ func (nc *Client) GetWork(params ...interface{}) (work *Work, err error) {
 
 // Hashrate returns the number of hashes per second that the node is mining with.
 func (nc *Client) Hashrate() (hashrate float64, err error) {
-	rpcResp, err := nc.Call("hashrate", nil)
-	if err != nil {
-		return 0, err
-	}
+	return nc.HashrateContext(context.Background())
+}
 
-	err = rpcResp.GetObject(&hashrate)
+// HashrateContext is like Hashrate but uses ctx for cancellation and deadlines.
+func (nc *Client) HashrateContext(ctx context.Context) (hashrate float64, err error) {
+	err = nc.callFor(ctx, &hashrate, "hashrate", nil)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
 
 	return
@@ -400,18 +521,18 @@ func (nc *Client) Hashrate() (hashrate float64, err error) {
 
 // Log sets the log level of the node.
 func (nc *Client) Log(tag string, level LogLevel) (succes bool, err error) {
-	rpcResp, err := nc.Call("log", []interface{}{
+	return nc.LogContext(context.Background(), tag, level)
+}
+
+// LogContext is like Log but uses ctx for cancellation and deadlines.
+func (nc *Client) LogContext(ctx context.Context, tag string, level LogLevel) (succes bool, err error) {
+	err = nc.callFor(ctx, &succes, "log", []interface{}{
 		tag, level,
 	})
 	if err != nil {
 		return false, err
 	}
 
-	err = rpcResp.GetObject(&succes)
-	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
-	}
-
 	return
 }
 
@@ -419,65 +540,120 @@ func (nc *Client) Log(tag string, level LogLevel) (succes bool, err error) {
 // This will provide an overview of the number of transactions sorted into buckets
 // based on their fee per byte (in smallest unit).
 func (nc *Client) Mempool() (mempool *Mempool, err error) {
-	rpcResp, err := nc.Call("mempool", nil)
+	return nc.MempoolContext(context.Background())
+}
+
+// MempoolContext is like Mempool but uses ctx for cancellation and deadlines.
+func (nc *Client) MempoolContext(ctx context.Context) (mempool *Mempool, err error) {
+	rpcResp, err := nc.CallContext(ctx, "mempool", nil)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Mempool
-	err = rpcResp.GetObject(&result)
+	return decodeMempool("mempool", rpcResp)
+}
+
+// MempoolContent returns the transactions in the mempool. If includeTransactions is true it returns
+// the full transaction objects, if false only the hashes of the transactions will be returned.
+func (nc *Client) MempoolContent(includeTransactions bool) (content *MempoolContent, err error) {
+	return nc.MempoolContentContext(context.Background(), includeTransactions)
+}
+
+// MempoolContentContext is like MempoolContent but uses ctx for cancellation and deadlines.
+func (nc *Client) MempoolContentContext(ctx context.Context, includeTransactions bool) (content *MempoolContent, err error) {
+	rpcResp, err := nc.CallContext(ctx, "mempoolContent", includeTransactions)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return nil, err
 	}
 
-	if result.Total == 0 && len(result.Buckets) == 0 {
-		return nil, nil
-	}
+	return decodeMempoolContent("mempoolContent", rpcResp, includeTransactions)
+}
 
-	return &result, nil
+// MinFeePerByte returns the minimum fee per byte in Luna a transaction must pay to be accepted by the mempool.
+func (nc *Client) MinFeePerByte() (fee Luna, err error) {
+	return nc.MinFeePerByteContext(context.Background())
 }
 
-// Mining returns if client is actively mining new blocks.
-func (nc *Client) Mining() (status bool, err error) {
-	rpcResp, err := nc.Call("mining", nil)
+// MinFeePerByteContext is like MinFeePerByte but uses ctx for cancellation and deadlines.
+func (nc *Client) MinFeePerByteContext(ctx context.Context) (fee Luna, err error) {
+	err = nc.callFor(ctx, &fee, "minFeePerByte", nil)
 	if err != nil {
-		return false, err
+		return 0, err
 	}
 
-	err = rpcResp.GetObject(&status)
+	return
+}
+
+// MinerAddress returns the user friendly address the miner is mining to.
+func (nc *Client) MinerAddress() (address string, err error) {
+	return nc.MinerAddressContext(context.Background())
+}
+
+// MinerAddressContext is like MinerAddress but uses ctx for cancellation and deadlines.
+func (nc *Client) MinerAddressContext(ctx context.Context) (address string, err error) {
+	err = nc.callFor(ctx, &address, "minerAddress", nil)
 	if err != nil {
-		return false, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", err
 	}
 
 	return
 }
 
-// PeerCount returns number of peers currently connected to the client.
-func (nc *Client) PeerCount() (peers int, err error) {
-	rpcResp, err := nc.Call("peerCount", nil)
+// MinerThreads returns the number of CPU threads the miner is using.
+func (nc *Client) MinerThreads() (threads int, err error) {
+	return nc.MinerThreadsContext(context.Background())
+}
+
+// MinerThreadsContext is like MinerThreads but uses ctx for cancellation and deadlines.
+func (nc *Client) MinerThreadsContext(ctx context.Context) (threads int, err error) {
+	err = nc.callFor(ctx, &threads, "minerThreads", nil)
 	if err != nil {
 		return 0, err
 	}
 
-	err = rpcResp.GetObject(&peers)
+	return
+}
+
+// Mining returns if client is actively mining new blocks.
+func (nc *Client) Mining() (status bool, err error) {
+	return nc.MiningContext(context.Background())
+}
+
+// MiningContext is like Mining but uses ctx for cancellation and deadlines.
+func (nc *Client) MiningContext(ctx context.Context) (status bool, err error) {
+	err = nc.callFor(ctx, &status, "mining", nil)
 	if err != nil {
-		return 0, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return false, err
 	}
 
 	return
 }
 
-// PeerList returns a list of peers currently connected to the client
-func (nc *Client) PeerList() (peers []Peer, err error) {
-	rpcResp, err := nc.Call("peerList", nil)
+// PeerCount returns number of peers currently connected to the client.
+func (nc *Client) PeerCount() (peers int, err error) {
+	return nc.PeerCountContext(context.Background())
+}
+
+// PeerCountContext is like PeerCount but uses ctx for cancellation and deadlines.
+func (nc *Client) PeerCountContext(ctx context.Context) (peers int, err error) {
+	err = nc.callFor(ctx, &peers, "peerCount", nil)
 	if err != nil {
-		return []Peer{}, err
+		return 0, err
 	}
 
-	err = rpcResp.GetObject(&peers)
+	return
+}
+
+// PeerList returns a list of peers currently connected to the client
+func (nc *Client) PeerList() (peers []Peer, err error) {
+	return nc.PeerListContext(context.Background())
+}
+
+// PeerListContext is like PeerList but uses ctx for cancellation and deadlines.
+func (nc *Client) PeerListContext(ctx context.Context) (peers []Peer, err error) {
+	err = nc.callFor(ctx, &peers, "peerList", nil)
 	if err != nil {
-		return []Peer{}, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return []Peer{}, err
 	}
 
 	return
@@ -486,37 +662,99 @@ func (nc *Client) PeerList() (peers []Peer, err error) {
 // PeerState returns the state for the given peer address. If update is set, the state
 // of the peer will be set to the given update parameter
 func (nc *Client) PeerState(peerAddress string, update ...string) (peer *Peer, err error) {
+	return nc.PeerStateContext(context.Background(), peerAddress, update...)
+}
+
+// PeerStateContext is like PeerState but uses ctx for cancellation and deadlines.
+func (nc *Client) PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *Peer, err error) {
 	params := []interface{}{peerAddress}
 	if len(update) > 0 {
 		if update[0] == "ban" || update[0] == "unban" || update[0] == "connect" || update[0] == "disconnect" {
 			params = append(params, update[0])
 		}
 	}
-	rpcResp, err := nc.Call("peerState", params)
+	var result Peer
+	err = nc.callFor(ctx, &result, "peerState", params)
 	if err != nil {
 		return nil, err
 	}
 
-	// Unmarshal result
-	var result Peer
-	err = rpcResp.GetObject(&result)
+	return &result, nil
+}
+
+// Pool returns the mining pool the miner is connected to as host:port.
+// If the miner is not connected to a pool, an empty string is returned.
+func (nc *Client) Pool() (pool string, err error) {
+	return nc.PoolContext(context.Background())
+}
+
+// PoolContext is like Pool but uses ctx for cancellation and deadlines.
+func (nc *Client) PoolContext(ctx context.Context) (pool string, err error) {
+	err = nc.callFor(ctx, &pool, "pool", nil)
 	if err != nil {
-		return nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return "", err
 	}
 
-	return &result, nil
+	return
 }
 
-// SendRawTransaction sends a signed message call transaction or a contract creation, if the data field contains code.
-func (nc *Client) SendRawTransaction(signedTransaction string) (transactionHash string, err error) {
-	rpcResp, err := nc.Call("sendRawTransaction", signedTransaction)
+// PoolConfirmedBalance returns the confirmed balance in Luna of the miner at its mining pool.
+func (nc *Client) PoolConfirmedBalance() (balance Luna, err error) {
+	return nc.PoolConfirmedBalanceContext(context.Background())
+}
+
+// PoolConfirmedBalanceContext is like PoolConfirmedBalance but uses ctx for cancellation and deadlines.
+func (nc *Client) PoolConfirmedBalanceContext(ctx context.Context) (balance Luna, err error) {
+	err = nc.callFor(ctx, &balance, "poolConfirmedBalance", nil)
 	if err != nil {
-		return "", err
+		return 0, err
 	}
 
-	err = rpcResp.GetObject(&transactionHash)
+	return
+}
+
+// PoolConnectionState returns the state of the connection of the miner to its mining pool.
+func (nc *Client) PoolConnectionState() (state PoolConnectionState, err error) {
+	return nc.PoolConnectionStateContext(context.Background())
+}
+
+// PoolConnectionStateContext is like PoolConnectionState but uses ctx for cancellation and deadlines.
+func (nc *Client) PoolConnectionStateContext(ctx context.Context) (state PoolConnectionState, err error) {
+	err = nc.callFor(ctx, &state, "poolConnectionState", nil)
+	if err != nil {
+		return PoolConnectionStateClosed, err
+	}
+
+	return
+}
+
+// ResetConstant resets the constant with the given name to its default value and returns the value.
+func (nc *Client) ResetConstant(constant string) (value int, err error) {
+	return nc.ResetConstantContext(context.Background(), constant)
+}
+
+// ResetConstantContext is like ResetConstant but uses ctx for cancellation and deadlines.
+func (nc *Client) ResetConstantContext(ctx context.Context, constant string) (value int, err error) {
+	err = nc.callFor(ctx, &value, "constant", []interface{}{
+		constant, "reset",
+	})
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
+	}
+
+	return
+}
+
+// SendRawTransaction sends a signed message call transaction or a contract creation, if the data field contains code.
+func (nc *Client) SendRawTransaction(signedTransaction string) (transactionHash string, err error) {
+	return nc.SendRawTransactionContext(context.Background(), signedTransaction)
+}
+
+// SendRawTransactionContext is like SendRawTransaction but uses ctx for cancellation and deadlines.
+func (nc *Client) SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error) {
+	err = nc.callFor(ctx, &transactionHash, "sendRawTransaction", signedTransaction)
+	if err != nil {
+		return "", err
 	}
 
 	return
@@ -524,15 +762,98 @@ func (nc *Client) SendRawTransaction(signedTransaction string) (transactionHash
 
 // SendTransaction creates new message call transaction or a contract creation, if the data field contains code.
 func (nc *Client) SendTransaction(trn OutgoingTransaction) (transactionHash string, err error) {
-	rpcResp, err := nc.Call("sendTransaction", trn)
+	return nc.SendTransactionContext(context.Background(), trn)
+}
+
+// SendTransactionContext is like SendTransaction but uses ctx for cancellation and deadlines.
+func (nc *Client) SendTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHash string, err error) {
+	err = checkRemoteTransaction(trn)
+	if err != nil {
+		return "", err
+	}
+	err = nc.callFor(ctx, &transactionHash, "sendTransaction", trn)
 	if err != nil {
 		return "", err
 	}
+	return
+}
+
+// SetConstant overrides the value of the constant with the given name and returns the new value.
+// The constant keeps this value until it is reset with ResetConstant.
+func (nc *Client) SetConstant(constant string, value int) (newValue int, err error) {
+	return nc.SetConstantContext(context.Background(), constant, value)
+}
 
-	err = rpcResp.GetObject(&transactionHash)
+// SetConstantContext is like SetConstant but uses ctx for cancellation and deadlines.
+func (nc *Client) SetConstantContext(ctx context.Context, constant string, value int) (newValue int, err error) {
+	err = nc.callFor(ctx, &newValue, "constant", []interface{}{
+		constant, value,
+	})
 	if err != nil {
-		return "", fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+		return 0, err
 	}
+
+	return
+}
+
+// SetMinFeePerByte sets the minimum fee per byte in Luna a transaction must pay to be accepted by the mempool,
+// and returns the new value.
+func (nc *Client) SetMinFeePerByte(fee Luna) (newFee Luna, err error) {
+	return nc.SetMinFeePerByteContext(context.Background(), fee)
+}
+
+// SetMinFeePerByteContext is like SetMinFeePerByte but uses ctx for cancellation and deadlines.
+func (nc *Client) SetMinFeePerByteContext(ctx context.Context, fee Luna) (newFee Luna, err error) {
+	err = nc.callFor(ctx, &newFee, "minFeePerByte", fee)
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// SetMinerThreads sets the number of CPU threads the miner uses and returns the new value.
+func (nc *Client) SetMinerThreads(threads int) (newThreads int, err error) {
+	return nc.SetMinerThreadsContext(context.Background(), threads)
+}
+
+// SetMinerThreadsContext is like SetMinerThreads but uses ctx for cancellation and deadlines.
+func (nc *Client) SetMinerThreadsContext(ctx context.Context, threads int) (newThreads int, err error) {
+	err = nc.callFor(ctx, &newThreads, "minerThreads", threads)
+	if err != nil {
+		return 0, err
+	}
+
+	return
+}
+
+// SetMining starts or stops mining and returns whether the client is actively mining.
+func (nc *Client) SetMining(enabled bool) (status bool, err error) {
+	return nc.SetMiningContext(context.Background(), enabled)
+}
+
+// SetMiningContext is like SetMining but uses ctx for cancellation and deadlines.
+func (nc *Client) SetMiningContext(ctx context.Context, enabled bool) (status bool, err error) {
+	err = nc.callFor(ctx, &status, "mining", enabled)
+	if err != nil {
+		return false, err
+	}
+
+	return
+}
+
+// SetPool connects the miner to the mining pool at the given host:port and returns the pool.
+func (nc *Client) SetPool(pool string) (newPool string, err error) {
+	return nc.SetPoolContext(context.Background(), pool)
+}
+
+// SetPoolContext is like SetPool but uses ctx for cancellation and deadlines.
+func (nc *Client) SetPoolContext(ctx context.Context, pool string) (newPool string, err error) {
+	err = nc.callFor(ctx, &newPool, "pool", pool)
+	if err != nil {
+		return "", err
+	}
+
 	return
 }
 
@@ -540,16 +861,28 @@ func (nc *Client) SendTransaction(trn OutgoingTransaction) (transactionHash stri
 // The argument is a hex-encoded full block (including header, interlink and body).
 // When submitting work from getWork, remember to include the suffix.
 func (nc *Client) SubmitBlock(fullBlock string) (err error) {
-	_, err = nc.Call("submitBlock", fullBlock)
-	return
+	return nc.SubmitBlockContext(context.Background(), fullBlock)
+}
+
+// SubmitBlockContext is like SubmitBlock but uses ctx for cancellation and deadlines.
+func (nc *Client) SubmitBlockContext(ctx context.Context, fullBlock string) (err error) {
+	return nc.callFor(ctx, nil, "submitBlock", fullBlock)
 }
 
 // Syncing returns whether the node is syncing and when it is syncing, data about the sync status.
 func (nc *Client) Syncing() (syncing bool, syncStatus *SyncStatus, err error) {
-	rpcResp, err := nc.Call("syncing", nil)
+	return nc.SyncingContext(context.Background())
+}
+
+// SyncingContext is like Syncing but uses ctx for cancellation and deadlines.
+func (nc *Client) SyncingContext(ctx context.Context) (syncing bool, syncStatus *SyncStatus, err error) {
+	rpcResp, err := nc.CallContext(ctx, "syncing", nil)
 	if err != nil {
 		return false, nil, err
 	}
+	if rpcResp.Error != nil {
+		return false, nil, newRPCError("syncing", rpcResp.Error)
+	}
 
 	// Unmarshal result
 	var result SyncStatus
@@ -558,7 +891,7 @@ func (nc *Client) Syncing() (syncing bool, syncStatus *SyncStatus, err error) {
 		var boolResult bool
 		err = rpcResp.GetObject(&boolResult)
 		if err != nil {
-			return false, nil, fmt.Errorf("%v: %v", ErrResultUnexpected, err)
+			return false, nil, fmt.Errorf("%w: %v", ErrResultUnexpected, err)
 		}
 
 		return false, nil, nil
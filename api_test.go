@@ -12,13 +12,18 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package nimiqrpc
+package nimiqrpc_test
 
 import (
+	"encoding/json"
 	"flag"
+	"io/ioutil"
 	"log"
 	"os"
 	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
 )
 
 var (
@@ -26,13 +31,25 @@ var (
 	auth         bool
 	authUsername string
 	authPassword string
-	client       *Client
+	client       *nimiqrpc.Client
+	fixture      *seed
 )
 
-// Initial test setup. These tests require a Nimiq node to test, so flags require to be provided.
-// If a node address is not provided, the tests that require a node will be skipped.
-// Test cases are skipped on individual basis, so other tests that do not have this dependency
-// can still take place.
+// seed holds the data that is seeded into the fake node. It is nil when the tests are run
+// against a Nimiq node, in which case the tests that depend on it are skipped.
+type seed struct {
+	wallet      *nimiqrpc.Wallet // account owned by the node
+	account     nimiqrpc.Account // sender of the transactions, after the first transaction is mined
+	block       nimiqrpc.Block   // head block, which includes the mined transaction
+	tx          string           // hash of the mined transaction
+	pending     string           // hash of the transaction in the mempool
+	minerAddr   string           // user friendly address of the miner
+	blockNumber int              // height of the head block
+	peer        nimiqrpc.Peer    // the only peer of the node
+}
+
+// Initial test setup. These tests can be run against a Nimiq node by providing its address with flags.
+// If a node address is not provided, the tests are run against the fake node of the nimiqtest package.
 func TestMain(m *testing.M) {
 	flag.StringVar(&nodeAddr, "node-addr", "", "The address of the Nimiq RPC node that can be used to test this library")
 	flag.BoolVar(&auth, "auth", false, "Use authentication to the Nimiq RPC node to test RPC calls")
@@ -40,28 +57,84 @@ func TestMain(m *testing.M) {
 	flag.StringVar(&authPassword, "password", "", "The password for authentication")
 	flag.Parse()
 
-	client = NewClient(nodeAddr)
+	var srv *nimiqtest.Server
+	if nodeAddr == "" {
+		srv = nimiqtest.NewServer()
+		nodeAddr = srv.URL
+		if err := seedServer(srv); err != nil {
+			log.Printf("Seeding the fake node failed: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	client = nimiqrpc.NewClient(nodeAddr)
 	if auth {
 		if authPassword == "" || authUsername == "" {
 			log.Println("Auth enabled but username or password is not provided")
 			os.Exit(1)
 		}
-		client = NewClientWithAuth(nodeAddr, authUsername, authPassword)
+		client = nimiqrpc.NewClientWithAuth(nodeAddr, authUsername, authPassword)
 	}
 
-	os.Exit(m.Run())
+	code := m.Run()
+	if srv != nil {
+		srv.Close()
+	}
+	os.Exit(code)
 }
 
-func TestBatch(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.CallBatch: No node address provided")
-		t.Skip("No node address provided")
+// seedServer seeds an owned account, a peer, a mined and a pending transaction into srv
+func seedServer(srv *nimiqtest.Server) error {
+	wallet, err := srv.Client().CreateAccount()
+	if err != nil {
+		return err
+	}
+
+	peer := nimiqrpc.Peer{
+		ID:              "b99034c552e9c0fd34eb95c1cdf17f5e",
+		Address:         "wss://seed-1.nimiq.com:8443/b99034c552e9c0fd34eb95c1cdf17f5e",
+		ConnectionState: 5,
+	}
+	srv.AddPeer(peer)
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+	tx, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: bob.Address, Value: 50000, Fee: 138})
+	if err != nil {
+		return err
+	}
+	block := srv.MineBlock()
+
+	pending, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{From: alice.Address, To: bob.Address, Value: 1000, Fee: 138})
+	if err != nil {
+		return err
+	}
+
+	fixture = &seed{
+		wallet:      wallet,
+		account:     srv.Account(alice.Address),
+		block:       block,
+		tx:          tx,
+		pending:     pending,
+		minerAddr:   block.MinerAddress,
+		blockNumber: srv.BlockNumber(),
+		peer:        peer,
+	}
+	return nil
+}
+
+// requireFixture skips the test if it is run against a Nimiq node instead of the seeded fake node
+func requireFixture(t *testing.T) {
+	if fixture == nil {
+		t.Skip("requires the data seeded into the fake node")
 	}
+}
 
+func TestBatch(t *testing.T) {
 	resp, err := client.CallBatch(
-		NewRequest("accounts"),
-		NewRequest("hashrate"),
-		NewRequest("blockNumber"),
+		nimiqrpc.NewRequest("accounts"),
+		nimiqrpc.NewRequest("hashrate"),
+		nimiqrpc.NewRequest("blockNumber"),
 	)
 
 	if err != nil {
@@ -74,320 +147,452 @@ func TestBatch(t *testing.T) {
 		t.FailNow()
 	}
 
+	if len(resp) != 3 {
+		log.Printf("FAILED: *client.CallBatch: expected 3 responses, got %d", len(resp))
+		t.FailNow()
+	}
+
 	log.Println("SUCCES: *client.CallBatch")
 }
 
 // Test client.Accounts()
 func TestClientAccounts(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Accounts: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.Accounts()
+	requireFixture(t)
+	accounts, err := client.Accounts()
 	if err != nil {
 		log.Printf("FAILED: *client.Accounts: %v", err)
 		t.FailNow()
 	}
+	if len(accounts) != 1 || accounts[0].Address != fixture.wallet.Address {
+		log.Printf("FAILED: *client.Accounts: expected the account %s, got %+v", fixture.wallet.Address, accounts)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Accounts")
 }
 
 // Test client.BlockNumber()
 func TestClientBlockNumber(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.BlockNumber: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.Accounts()
+	blockNumber, err := client.BlockNumber()
 	if err != nil {
 		log.Printf("FAILED: *client.BlockNumber: %v", err)
 		t.FailNow()
 	}
+	if blockNumber < 1 || (fixture != nil && blockNumber != fixture.blockNumber) {
+		log.Printf("FAILED: *client.BlockNumber: unexpected block number %d", blockNumber)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.BlockNumber")
 }
 
 // Test client.Consensus()
 func TestClientConsensus(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Consensus: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.Consensus()
+	consensus, err := client.Consensus()
 	if err != nil {
 		log.Printf("FAILED: *client.Consensus: %v", err)
 		t.FailNow()
 	}
+	if consensus == "" || (fixture != nil && consensus != nimiqrpc.ConsensusEstablished) {
+		log.Printf("FAILED: *client.Consensus: unexpected consensus %q", consensus)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Consensus")
 }
 
 // Test client.GetAccount()
 func TestClientGetAccount(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetAccount: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetAccount("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	requireFixture(t)
+	account, err := client.GetAccount(fixture.account.Address)
 	if err != nil {
 		log.Printf("FAILED: *client.GetAccount: %v", err)
 		t.FailNow()
 	}
+	if account == nil || *account != fixture.account {
+		log.Printf("FAILED: *client.GetAccount: expected %+v, got %+v", fixture.account, account)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetAccount")
 }
 
-// Test client.GetBalance()
-func TestClientGetBalance(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBalance: No node address provided")
-		t.Skip("No node address provided")
+// Test client.GetTypedAccount()
+func TestClientGetTypedAccount(t *testing.T) {
+	requireFixture(t)
+	account, err := client.GetTypedAccount(fixture.account.Address)
+	if err != nil {
+		log.Printf("FAILED: *client.GetTypedAccount: %v", err)
+		t.FailNow()
+	}
+	if basic, ok := account.(*nimiqrpc.BasicAccount); !ok || basic.Flat() != fixture.account {
+		log.Printf("FAILED: *client.GetTypedAccount: expected %+v, got %+v", fixture.account, account)
+		t.FailNow()
 	}
+	log.Println("SUCCES: *client.GetTypedAccount")
+}
 
-	_, err := client.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+// Test client.GetBalance()
+func TestClientGetBalance(t *testing.T) {
+	requireFixture(t)
+	balance, err := client.GetBalance(fixture.account.Address)
 	if err != nil {
 		log.Printf("FAILED: *client.GetBalance: %v", err)
 		t.FailNow()
 	}
+	if balance != 100000-50000-138 {
+		log.Printf("FAILED: *client.GetBalance: expected 49862, got %d", balance)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBalance")
 }
 
 // Test client.GetBlockByHash()
 func TestClientGetBlockByHash(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBlockByHash: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetBlockByHash("2f04512d8c80193f465aedf48160c6b5346f8c061a947c3f5734ad821e482065", false)
+	requireFixture(t)
+	block, err := client.GetBlockByHash(fixture.block.Hash, false)
 	if err != nil {
 		log.Printf("FAILED: *client.GetBlockByHash: %v", err)
 		t.FailNow()
 	}
+	if block == nil || block.Number != fixture.block.Number || len(block.TransactionHashes) != 1 || block.TransactionHashes[0] != fixture.tx {
+		log.Printf("FAILED: *client.GetBlockByHash: expected block %d with transaction %s, got %+v", fixture.block.Number, fixture.tx, block)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBlockByHash")
 }
 
 // Test client.GetBlockByNumber()
 func TestClientGetBlockByNumber(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBlockByNumber: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetBlockByNumber(684057, false)
+	requireFixture(t)
+	block, err := client.GetBlockByNumber(fixture.block.Number, true)
 	if err != nil {
 		log.Printf("FAILED: *client.GetBlockByNumber: %v", err)
 		t.FailNow()
 	}
+	if block == nil || block.Hash != fixture.block.Hash || len(block.TransactionObjects) != 1 || block.TransactionObjects[0].Hash != fixture.tx {
+		log.Printf("FAILED: *client.GetBlockByNumber: expected block %s with transaction %s, got %+v", fixture.block.Hash, fixture.tx, block)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBlockByNumber")
 }
 
 // Test client.GetBlockTemplate()
 func TestClientGetBlockTemplate(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBlockTemplate: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetBlockTemplate()
+	requireFixture(t)
+	template, err := client.GetBlockTemplate()
 	if err != nil {
 		log.Printf("FAILED: *client.GetBlockTemplate: %v", err)
 		t.FailNow()
 	}
+	if template == nil || template.Header.Height != fixture.blockNumber+1 || template.Header.PrevHash != fixture.block.Hash {
+		log.Printf("FAILED: *client.GetBlockTemplate: expected a template on top of %s, got %+v", fixture.block.Hash, template)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBlockTemplate")
 }
 
 // Test client.GetBlockTransactionCountByHash()
 func TestClientGetBlockTransactionCountByHash(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBlockTransactionCountByHash: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetBlockTransactionCountByHash("2f04512d8c80193f465aedf48160c6b5346f8c061a947c3f5734ad821e482065")
+	requireFixture(t)
+	count, err := client.GetBlockTransactionCountByHash(fixture.block.Hash)
 	if err != nil {
 		log.Printf("FAILED: *client.GetBlockTransactionCountByHash: %v", err)
 		t.FailNow()
 	}
+	if count != 1 {
+		log.Printf("FAILED: *client.GetBlockTransactionCountByHash: expected 1, got %d", count)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBlockTransactionCountByHash")
 }
 
 // Test client.GetBlockTransactionCountByNumber()
 func TestClientGetBlockTransactionCountByNumber(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetBlockTransactionCountByNumber: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetBlockTransactionCountByNumber(684057)
+	requireFixture(t)
+	count, err := client.GetBlockTransactionCountByNumber(fixture.block.Number)
 	if err != nil {
 		log.Printf("FAILED: *client.GetBlockTransactionCountByNumber: %v", err)
 		t.FailNow()
 	}
+	if count != 1 {
+		log.Printf("FAILED: *client.GetBlockTransactionCountByNumber: expected 1, got %d", count)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetBlockTransactionCountByNumber")
 }
 
 // Test client.GetTransactionByBlockHashAndIndex
 func TestClientGetTransactionByBlockHashAndIndex(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetTransactionByBlockHashAndIndex: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetTransactionByBlockHashAndIndex("2f04512d8c80193f465aedf48160c6b5346f8c061a947c3f5734ad821e482065", 0)
+	requireFixture(t)
+	tx, err := client.GetTransactionByBlockHashAndIndex(fixture.block.Hash, 0)
 	if err != nil {
 		log.Printf("FAILED: *client.GetTransactionByBlockHashAndIndex: %v", err)
 		t.FailNow()
 	}
+	if tx == nil || tx.Hash != fixture.tx {
+		log.Printf("FAILED: *client.GetTransactionByBlockHashAndIndex: expected %s, got %+v", fixture.tx, tx)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetTransactionByBlockHashAndIndex")
 }
 
 // Test client.GetTransactionByBlockNumberAndIndex
 func TestClientGetTransactionByBlockNumberAndIndex(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetTransactionByBlockNumberAndIndex: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetTransactionByBlockNumberAndIndex(684057, 0)
+	requireFixture(t)
+	tx, err := client.GetTransactionByBlockNumberAndIndex(fixture.block.Number, 0)
 	if err != nil {
 		log.Printf("FAILED: *client.GetTransactionByBlockNumberAndIndex: %v", err)
 		t.FailNow()
 	}
+	if tx == nil || tx.Hash != fixture.tx {
+		log.Printf("FAILED: *client.GetTransactionByBlockNumberAndIndex: expected %s, got %+v", fixture.tx, tx)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetTransactionByBlockNumberAndIndex")
 }
 
 // Test client.GetTransactionByHash
 func TestClientGetTransactionByHash(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetTransactionByHash: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetTransactionByHash("ec8f1de17ce277af66d5a4e7c4d490313879115cd68f38284e79096b758aeb70")
+	requireFixture(t)
+	tx, err := client.GetTransactionByHash(fixture.tx)
 	if err != nil {
 		log.Printf("FAILED: *client.GetTransactionByHash: %v", err)
 		t.FailNow()
 	}
+	if tx == nil || tx.FromAddress != fixture.account.Address || tx.Value != 50000 || tx.Fee != 138 || tx.BlockHash != fixture.block.Hash {
+		log.Printf("FAILED: *client.GetTransactionByHash: unexpected transaction %+v", tx)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetTransactionByHash")
 }
 
 // Test client.GetTransactionReceipt
 func TestClientGetTransactionReceipt(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetTransactionReceipt: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetTransactionReceipt("ec8f1de17ce277af66d5a4e7c4d490313879115cd68f38284e79096b758aeb70")
+	requireFixture(t)
+	receipt, err := client.GetTransactionReceipt(fixture.tx)
 	if err != nil {
 		log.Printf("FAILED: *client.GetTransactionReceipt: %v", err)
 		t.FailNow()
 	}
+	if receipt == nil || receipt.BlockHash != fixture.block.Hash || receipt.Confirmations != 1 {
+		log.Printf("FAILED: *client.GetTransactionReceipt: unexpected receipt %+v", receipt)
+		t.FailNow()
+	}
+
+	// A transaction in the mempool has no receipt
+	receipt, err = client.GetTransactionReceipt(fixture.pending)
+	if err != nil || receipt != nil {
+		log.Printf("FAILED: *client.GetTransactionReceipt: expected no receipt for a pending transaction, got %+v, %v", receipt, err)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetTransactionReceipt")
 }
 
 // Test client.GetWork
 func TestClientGetWork(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.GetWork: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.GetWork()
+	requireFixture(t)
+	work, err := client.GetWork()
 	if err != nil {
 		log.Printf("FAILED: *client.GetWork: %v", err)
 		t.FailNow()
 	}
+	if work == nil || work.Data != fixture.block.Hash || work.Algorithm != "nimiq-argon2" {
+		log.Printf("FAILED: *client.GetWork: unexpected work %+v", work)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.GetWork")
 }
 
 // Test client.Hashrate
 func TestClientHashrate(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Hashrate: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.Hashrate()
+	requireFixture(t)
+	hashrate, err := client.Hashrate()
 	if err != nil {
 		log.Printf("FAILED: *client.Hashrate: %v", err)
 		t.FailNow()
 	}
+	if hashrate != 0 {
+		log.Printf("FAILED: *client.Hashrate: expected 0 when not mining, got %f", hashrate)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Hashrate")
 }
 
 // Test client.Mempool
 func TestClientMempool(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Mempool: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.Mempool()
+	requireFixture(t)
+	mempool, err := client.Mempool()
 	if err != nil {
 		log.Printf("FAILED: *client.Mempool: %v", err)
 		t.FailNow()
 	}
+	if mempool == nil || mempool.Total != 1 || len(mempool.Buckets) != 1 || mempool.Buckets[0] != 1 || mempool.Bucket1 != 1 {
+		log.Printf("FAILED: *client.Mempool: expected one transaction in bucket 1, got %+v", mempool)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Mempool")
 }
 
-// Test client.Mining
-func TestClientMining(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Mining: No node address provided")
-		t.Skip("No node address provided")
+// Test client.MempoolContent
+func TestClientMempoolContent(t *testing.T) {
+	requireFixture(t)
+	content, err := client.MempoolContent(true)
+	if err != nil {
+		log.Printf("FAILED: *client.MempoolContent: %v", err)
+		t.FailNow()
+	}
+	if content == nil || len(content.TransactionObjects) != 1 || content.TransactionObjects[0].Hash != fixture.pending {
+		log.Printf("FAILED: *client.MempoolContent: expected transaction %s, got %+v", fixture.pending, content)
+		t.FailNow()
 	}
+	log.Println("SUCCES: *client.MempoolContent")
+}
+
+// Test client.MinFeePerByte
+func TestClientMinFeePerByte(t *testing.T) {
+	requireFixture(t)
+	fee, err := client.MinFeePerByte()
+	if err != nil {
+		log.Printf("FAILED: *client.MinFeePerByte: %v", err)
+		t.FailNow()
+	}
+	if fee != 0 {
+		log.Printf("FAILED: *client.MinFeePerByte: expected 0, got %d", fee)
+		t.FailNow()
+	}
+	log.Println("SUCCES: *client.MinFeePerByte")
+}
 
-	_, err := client.Mining()
+// Test client.MinerAddress
+func TestClientMinerAddress(t *testing.T) {
+	requireFixture(t)
+	address, err := client.MinerAddress()
+	if err != nil {
+		log.Printf("FAILED: *client.MinerAddress: %v", err)
+		t.FailNow()
+	}
+	if address != fixture.minerAddr {
+		log.Printf("FAILED: *client.MinerAddress: expected %s, got %s", fixture.minerAddr, address)
+		t.FailNow()
+	}
+	log.Println("SUCCES: *client.MinerAddress")
+}
+
+// Test client.MinerThreads
+func TestClientMinerThreads(t *testing.T) {
+	requireFixture(t)
+	threads, err := client.MinerThreads()
+	if err != nil {
+		log.Printf("FAILED: *client.MinerThreads: %v", err)
+		t.FailNow()
+	}
+	if threads != 1 {
+		log.Printf("FAILED: *client.MinerThreads: expected 1, got %d", threads)
+		t.FailNow()
+	}
+	log.Println("SUCCES: *client.MinerThreads")
+}
+
+// Test client.Mining
+func TestClientMining(t *testing.T) {
+	requireFixture(t)
+	mining, err := client.Mining()
 	if err != nil {
 		log.Printf("FAILED: *client.Mining: %v", err)
 		t.FailNow()
 	}
+	if mining {
+		log.Printf("FAILED: *client.Mining: expected the node not to be mining")
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Mining")
 }
 
 // Test client.PeerCount
 func TestClientPeerCount(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.PeerCount: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.PeerCount()
+	requireFixture(t)
+	count, err := client.PeerCount()
 	if err != nil {
 		log.Printf("FAILED: *client.PeerCount: %v", err)
 		t.FailNow()
 	}
+	if count != 1 {
+		log.Printf("FAILED: *client.PeerCount: expected 1, got %d", count)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.PeerCount")
 }
 
 // Test client.PeerList
 func TestClientPeerList(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.PeerList: No node address provided")
-		t.Skip("No node address provided")
-	}
-
-	_, err := client.PeerList()
+	requireFixture(t)
+	peers, err := client.PeerList()
 	if err != nil {
 		log.Printf("FAILED: *client.PeerList: %v", err)
 		t.FailNow()
 	}
+	if len(peers) != 1 || peers[0] != fixture.peer {
+		log.Printf("FAILED: *client.PeerList: expected %+v, got %+v", fixture.peer, peers)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.PeerList")
 }
 
-// Test client.Syncing
-func TestClientSyncing(t *testing.T) {
-	if nodeAddr == "" {
-		log.Println("SKIPPED: *client.Syncing: No node address provided")
-		t.Skip("No node address provided")
+// Test client.PoolConnectionState
+func TestClientPoolConnectionState(t *testing.T) {
+	requireFixture(t)
+	state, err := client.PoolConnectionState()
+	if err != nil {
+		log.Printf("FAILED: *client.PoolConnectionState: %v", err)
+		t.FailNow()
+	}
+	if state != nimiqrpc.PoolConnectionStateClosed {
+		log.Printf("FAILED: *client.PoolConnectionState: expected closed, got %d", state)
+		t.FailNow()
 	}
+	log.Println("SUCCES: *client.PoolConnectionState")
+}
 
-	_, _, err := client.Syncing()
+// Test client.Syncing
+func TestClientSyncing(t *testing.T) {
+	requireFixture(t)
+	syncing, status, err := client.Syncing()
 	if err != nil {
 		log.Printf("FAILED: *client.Syncing: %v", err)
 		t.FailNow()
 	}
+	if syncing || status != nil {
+		log.Printf("FAILED: *client.Syncing: expected the node not to be syncing, got %+v", status)
+		t.FailNow()
+	}
 	log.Println("SUCCES: *client.Syncing")
 }
+
+// Test nimiqrpc.TransactionHash against the hashes a Nimiq node reports for the transactions in testdata
+func TestNodeTransactionHash(t *testing.T) {
+	if fixture != nil {
+		t.Skip("requires a Nimiq node, the fake node hashes transactions with this package")
+	}
+	data, err := ioutil.ReadFile("testdata/transactions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transactions []struct {
+		Name        string `json:"name"`
+		Transaction string `json:"transaction"`
+	}
+	if err := json.Unmarshal(data, &transactions); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, trn := range transactions {
+		info, err := client.GetRawTransactionInfo(trn.Transaction)
+		if err != nil {
+			log.Printf("FAILED: *client.GetRawTransactionInfo: %s: %v", trn.Name, err)
+			t.FailNow()
+		}
+		hash, err := nimiqrpc.TransactionHash(trn.Transaction)
+		if err != nil || hash != info.Hash {
+			log.Printf("FAILED: nimiqrpc.TransactionHash: %s: node reports %s, got %s, %v", trn.Name, info.Hash, hash, err)
+			t.FailNow()
+		}
+	}
+	log.Println("SUCCES: nimiqrpc.TransactionHash")
+}
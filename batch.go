@@ -0,0 +1,566 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+var (
+	// ErrBatchNotExecuted is returned by a BatchCall of which the batch is not yet executed
+	ErrBatchNotExecuted = errors.New("batch not executed")
+
+	// ErrBatchExecuted is returned when a batch is executed more than once
+	ErrBatchExecuted = errors.New("batch already executed")
+)
+
+// Batch queues RPC calls that are sent to the node in a single batch request. Every queued call
+// returns a handle, of which the result can be read after the batch is executed. The results are
+// decoded into the same types as the results of the methods of Client.
+//
+//   batch := nimiqClient.NewBatch()
+//   balance := batch.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+//   block := batch.GetBlockByNumber(684057, true)
+//
+//   err := batch.Execute()
+//   if err != nil {
+//       panic(err)
+//   }
+//
+//   luna, err := balance.Result()
+type Batch struct {
+	client   *Client
+	calls    []*BatchCall
+	executed bool
+}
+
+// NewBatch returns a new empty Batch
+func (nc *Client) NewBatch() *Batch {
+	return &Batch{
+		client: nc,
+	}
+}
+
+// BatchCall is a call that is queued in a Batch
+type BatchCall struct {
+	method string
+	params []interface{}
+	decode func(rpcResp *jsonrpc.RPCResponse) error
+	err    error
+}
+
+// Method returns the RPC method of the call
+func (bc *BatchCall) Method() string {
+	return bc.method
+}
+
+// Err returns the error of the call. This is ErrBatchNotExecuted until the batch is executed.
+func (bc *BatchCall) Err() error {
+	return bc.err
+}
+
+// Len returns the number of queued calls
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Execute sends all queued calls to the node in a single batch request
+func (b *Batch) Execute() error {
+	return b.ExecuteContext(context.Background())
+}
+
+// ExecuteContext is like Execute but uses ctx for cancellation and deadlines.
+// The returned error only reports failures of the batch request as a whole,
+// the errors of the individual calls are reported by their handles.
+func (b *Batch) ExecuteContext(ctx context.Context) error {
+	if b.executed {
+		return ErrBatchExecuted
+	}
+	b.executed = true
+
+	if len(b.calls) == 0 {
+		return nil
+	}
+
+	reqs := make([]*jsonrpc.RPCRequest, 0, len(b.calls))
+	for _, call := range b.calls {
+		reqs = append(reqs, jsonrpc.NewRequest(call.method, call.params...))
+	}
+
+	rpcResps, err := b.client.CallBatchContext(ctx, reqs...)
+	if err != nil {
+		for _, call := range b.calls {
+			call.err = err
+		}
+		return err
+	}
+
+	// Responses are matched to their calls by ID, which is set to the position of the
+	// call in the batch. Responses may be received in a different order.
+	byID := rpcResps.AsMap()
+	for i, call := range b.calls {
+		rpcResp, ok := byID[i]
+		if !ok {
+			call.err = fmt.Errorf("%w: no response to %s()", ErrResultUnexpected, call.method)
+			continue
+		}
+		call.err = call.decode(rpcResp)
+	}
+
+	return nil
+}
+
+// add queues a call in the batch
+func (b *Batch) add(call *BatchCall, method string, params []interface{}, decode func(rpcResp *jsonrpc.RPCResponse) error) {
+	call.method = method
+	call.params = params
+	call.decode = decode
+	call.err = ErrBatchNotExecuted
+	b.calls = append(b.calls, call)
+}
+
+// into returns a decode function that decodes the result of method into out
+func into(method string, out interface{}) func(rpcResp *jsonrpc.RPCResponse) error {
+	return func(rpcResp *jsonrpc.RPCResponse) error {
+		return decodeResult(method, rpcResp, out)
+	}
+}
+
+// AccountCall is a queued call resulting in an account
+type AccountCall struct {
+	BatchCall
+	account Account
+}
+
+// Result returns the account, or the error of the call
+func (c *AccountCall) Result() (*Account, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &c.account, nil
+}
+
+// TypedAccountCall is a queued call resulting in an account decoded according to its type
+type TypedAccountCall struct {
+	BatchCall
+	account Account
+}
+
+// Result returns the typed account, or the error of the call
+func (c *TypedAccountCall) Result() (TypedAccount, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.account.Typed(), nil
+}
+
+// AccountsCall is a queued call resulting in a list of accounts
+type AccountsCall struct {
+	BatchCall
+	accounts []Account
+}
+
+// Result returns the accounts, or the error of the call
+func (c *AccountsCall) Result() ([]Account, error) {
+	return c.accounts, c.err
+}
+
+// BlockCall is a queued call resulting in a block
+type BlockCall struct {
+	BatchCall
+	block *Block
+}
+
+// Result returns the block, or the error of the call. The block is nil if it was not found.
+func (c *BlockCall) Result() (*Block, error) {
+	return c.block, c.err
+}
+
+// BoolCall is a queued call resulting in a boolean
+type BoolCall struct {
+	BatchCall
+	value bool
+}
+
+// Result returns the boolean, or the error of the call
+func (c *BoolCall) Result() (bool, error) {
+	return c.value, c.err
+}
+
+// FloatCall is a queued call resulting in a floating point number
+type FloatCall struct {
+	BatchCall
+	value float64
+}
+
+// Result returns the number, or the error of the call
+func (c *FloatCall) Result() (float64, error) {
+	return c.value, c.err
+}
+
+// IntCall is a queued call resulting in an integer
+type IntCall struct {
+	BatchCall
+	value int
+}
+
+// Result returns the integer, or the error of the call
+func (c *IntCall) Result() (int, error) {
+	return c.value, c.err
+}
+
+// LunaCall is a queued call resulting in an amount of Luna
+type LunaCall struct {
+	BatchCall
+	value Luna
+}
+
+// Result returns the amount, or the error of the call
+func (c *LunaCall) Result() (Luna, error) {
+	return c.value, c.err
+}
+
+// MempoolCall is a queued call resulting in mempool information
+type MempoolCall struct {
+	BatchCall
+	mempool *Mempool
+}
+
+// Result returns the mempool information, or the error of the call. The mempool is nil if it is empty.
+func (c *MempoolCall) Result() (*Mempool, error) {
+	return c.mempool, c.err
+}
+
+// MempoolContentCall is a queued call resulting in the content of the mempool
+type MempoolContentCall struct {
+	BatchCall
+	content *MempoolContent
+}
+
+// Result returns the content of the mempool, or the error of the call
+func (c *MempoolContentCall) Result() (*MempoolContent, error) {
+	return c.content, c.err
+}
+
+// PeersCall is a queued call resulting in a list of peers
+type PeersCall struct {
+	BatchCall
+	peers []Peer
+}
+
+// Result returns the peers, or the error of the call
+func (c *PeersCall) Result() ([]Peer, error) {
+	return c.peers, c.err
+}
+
+// PoolConnectionStateCall is a queued call resulting in a pool connection state
+type PoolConnectionStateCall struct {
+	BatchCall
+	state PoolConnectionState
+}
+
+// Result returns the pool connection state, or the error of the call
+func (c *PoolConnectionStateCall) Result() (PoolConnectionState, error) {
+	return c.state, c.err
+}
+
+// RawTransactionInfoCall is a queued call resulting in information about a raw transaction
+type RawTransactionInfoCall struct {
+	BatchCall
+	info RawTransactionInfo
+}
+
+// Result returns the information about the transaction, or the error of the call
+func (c *RawTransactionInfoCall) Result() (*RawTransactionInfo, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &c.info, nil
+}
+
+// StringCall is a queued call resulting in a string
+type StringCall struct {
+	BatchCall
+	value string
+}
+
+// Result returns the string, or the error of the call
+func (c *StringCall) Result() (string, error) {
+	return c.value, c.err
+}
+
+// TransactionCall is a queued call resulting in a transaction
+type TransactionCall struct {
+	BatchCall
+	transaction *Transaction
+}
+
+// Result returns the transaction, or the error of the call. The transaction is nil if it was not found.
+func (c *TransactionCall) Result() (*Transaction, error) {
+	return c.transaction, c.err
+}
+
+// TransactionsCall is a queued call resulting in a list of transactions
+type TransactionsCall struct {
+	BatchCall
+	transactions []Transaction
+}
+
+// Result returns the transactions, or the error of the call
+func (c *TransactionsCall) Result() ([]Transaction, error) {
+	return c.transactions, c.err
+}
+
+// TransactionReceiptCall is a queued call resulting in a transaction receipt
+type TransactionReceiptCall struct {
+	BatchCall
+	receipt *TransactionReceipt
+}
+
+// Result returns the receipt, or the error of the call. The receipt is nil if it was not found.
+func (c *TransactionReceiptCall) Result() (*TransactionReceipt, error) {
+	return c.receipt, c.err
+}
+
+// Accounts queues a call to Client.Accounts
+func (b *Batch) Accounts() *AccountsCall {
+	call := &AccountsCall{}
+	b.add(&call.BatchCall, "accounts", nil, into("accounts", &call.accounts))
+	return call
+}
+
+// BlockNumber queues a call to Client.BlockNumber
+func (b *Batch) BlockNumber() *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "blockNumber", nil, into("blockNumber", &call.value))
+	return call
+}
+
+// Consensus queues a call to Client.Consensus
+func (b *Batch) Consensus() *StringCall {
+	call := &StringCall{}
+	b.add(&call.BatchCall, "consensus", nil, into("consensus", &call.value))
+	return call
+}
+
+// Constant queues a call to Client.Constant
+func (b *Batch) Constant(constant string) *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "constant", []interface{}{constant}, into("constant", &call.value))
+	return call
+}
+
+// GetAccount queues a call to Client.GetAccount
+func (b *Batch) GetAccount(address string) *AccountCall {
+	call := &AccountCall{}
+	b.add(&call.BatchCall, "getAccount", []interface{}{address}, into("getAccount", &call.account))
+	return call
+}
+
+// GetTypedAccount queues a call to Client.GetTypedAccount
+func (b *Batch) GetTypedAccount(address string) *TypedAccountCall {
+	call := &TypedAccountCall{}
+	b.add(&call.BatchCall, "getAccount", []interface{}{address}, into("getAccount", &call.account))
+	return call
+}
+
+// GetBalance queues a call to Client.GetBalance
+func (b *Batch) GetBalance(address string) *LunaCall {
+	call := &LunaCall{}
+	b.add(&call.BatchCall, "getBalance", []interface{}{address}, into("getBalance", &call.value))
+	return call
+}
+
+// GetBlockByHash queues a call to Client.GetBlockByHash
+func (b *Batch) GetBlockByHash(blockHash string, fullTransactions bool) *BlockCall {
+	call := &BlockCall{}
+	b.add(&call.BatchCall, "getBlockByHash", []interface{}{blockHash, fullTransactions}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.block, err = decodeBlock("getBlockByHash", rpcResp, fullTransactions)
+		return
+	})
+	return call
+}
+
+// GetBlockByNumber queues a call to Client.GetBlockByNumber
+func (b *Batch) GetBlockByNumber(blockNumber int, fullTransactions bool) *BlockCall {
+	call := &BlockCall{}
+	b.add(&call.BatchCall, "getBlockByNumber", []interface{}{blockNumber, fullTransactions}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.block, err = decodeBlock("getBlockByNumber", rpcResp, fullTransactions)
+		return
+	})
+	return call
+}
+
+// GetBlockTransactionCountByHash queues a call to Client.GetBlockTransactionCountByHash
+func (b *Batch) GetBlockTransactionCountByHash(blockHash string) *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "getBlockTransactionCountByHash", []interface{}{blockHash}, into("getBlockTransactionCountByHash", &call.value))
+	return call
+}
+
+// GetBlockTransactionCountByNumber queues a call to Client.GetBlockTransactionCountByNumber
+func (b *Batch) GetBlockTransactionCountByNumber(blockNumber int) *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "getBlockTransactionCountByNumber", []interface{}{blockNumber}, into("getBlockTransactionCountByNumber", &call.value))
+	return call
+}
+
+// GetRawTransactionInfo queues a call to Client.GetRawTransactionInfo
+func (b *Batch) GetRawTransactionInfo(transactionHex string) *RawTransactionInfoCall {
+	call := &RawTransactionInfoCall{}
+	b.add(&call.BatchCall, "getRawTransactionInfo", []interface{}{transactionHex}, into("getRawTransactionInfo", &call.info))
+	return call
+}
+
+// GetTransactionByBlockHashAndIndex queues a call to Client.GetTransactionByBlockHashAndIndex
+func (b *Batch) GetTransactionByBlockHashAndIndex(blockHash string, index int) *TransactionCall {
+	call := &TransactionCall{}
+	b.add(&call.BatchCall, "getTransactionByBlockHashAndIndex", []interface{}{blockHash, index}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.transaction, err = decodeTransaction("getTransactionByBlockHashAndIndex", rpcResp)
+		return
+	})
+	return call
+}
+
+// GetTransactionByBlockNumberAndIndex queues a call to Client.GetTransactionByBlockNumberAndIndex
+func (b *Batch) GetTransactionByBlockNumberAndIndex(blockNumber int, index int) *TransactionCall {
+	call := &TransactionCall{}
+	b.add(&call.BatchCall, "getTransactionByBlockNumberAndIndex", []interface{}{blockNumber, index}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.transaction, err = decodeTransaction("getTransactionByBlockNumberAndIndex", rpcResp)
+		return
+	})
+	return call
+}
+
+// GetTransactionByHash queues a call to Client.GetTransactionByHash
+func (b *Batch) GetTransactionByHash(transactionHash string) *TransactionCall {
+	call := &TransactionCall{}
+	b.add(&call.BatchCall, "getTransactionByHash", []interface{}{transactionHash}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.transaction, err = decodeTransaction("getTransactionByHash", rpcResp)
+		return
+	})
+	return call
+}
+
+// GetTransactionReceipt queues a call to Client.GetTransactionReceipt
+func (b *Batch) GetTransactionReceipt(transactionHash string) *TransactionReceiptCall {
+	call := &TransactionReceiptCall{}
+	b.add(&call.BatchCall, "getTransactionReceipt", []interface{}{transactionHash}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.receipt, err = decodeTransactionReceipt("getTransactionReceipt", rpcResp)
+		return
+	})
+	return call
+}
+
+// GetTransactionsByAddress queues a call to Client.GetTransactionsByAddress
+func (b *Batch) GetTransactionsByAddress(address string, maxEntries int) *TransactionsCall {
+	call := &TransactionsCall{}
+	b.add(&call.BatchCall, "getTransactionsByAddress", []interface{}{address, maxEntries}, into("getTransactionsByAddress", &call.transactions))
+	return call
+}
+
+// Hashrate queues a call to Client.Hashrate
+func (b *Batch) Hashrate() *FloatCall {
+	call := &FloatCall{}
+	b.add(&call.BatchCall, "hashrate", nil, into("hashrate", &call.value))
+	return call
+}
+
+// Mempool queues a call to Client.Mempool
+func (b *Batch) Mempool() *MempoolCall {
+	call := &MempoolCall{}
+	b.add(&call.BatchCall, "mempool", nil, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.mempool, err = decodeMempool("mempool", rpcResp)
+		return
+	})
+	return call
+}
+
+// MempoolContent queues a call to Client.MempoolContent
+func (b *Batch) MempoolContent(includeTransactions bool) *MempoolContentCall {
+	call := &MempoolContentCall{}
+	b.add(&call.BatchCall, "mempoolContent", []interface{}{includeTransactions}, func(rpcResp *jsonrpc.RPCResponse) (err error) {
+		call.content, err = decodeMempoolContent("mempoolContent", rpcResp, includeTransactions)
+		return
+	})
+	return call
+}
+
+// MinFeePerByte queues a call to Client.MinFeePerByte
+func (b *Batch) MinFeePerByte() *LunaCall {
+	call := &LunaCall{}
+	b.add(&call.BatchCall, "minFeePerByte", nil, into("minFeePerByte", &call.value))
+	return call
+}
+
+// MinerAddress queues a call to Client.MinerAddress
+func (b *Batch) MinerAddress() *StringCall {
+	call := &StringCall{}
+	b.add(&call.BatchCall, "minerAddress", nil, into("minerAddress", &call.value))
+	return call
+}
+
+// MinerThreads queues a call to Client.MinerThreads
+func (b *Batch) MinerThreads() *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "minerThreads", nil, into("minerThreads", &call.value))
+	return call
+}
+
+// Mining queues a call to Client.Mining
+func (b *Batch) Mining() *BoolCall {
+	call := &BoolCall{}
+	b.add(&call.BatchCall, "mining", nil, into("mining", &call.value))
+	return call
+}
+
+// PeerCount queues a call to Client.PeerCount
+func (b *Batch) PeerCount() *IntCall {
+	call := &IntCall{}
+	b.add(&call.BatchCall, "peerCount", nil, into("peerCount", &call.value))
+	return call
+}
+
+// PeerList queues a call to Client.PeerList
+func (b *Batch) PeerList() *PeersCall {
+	call := &PeersCall{}
+	b.add(&call.BatchCall, "peerList", nil, into("peerList", &call.peers))
+	return call
+}
+
+// Pool queues a call to Client.Pool
+func (b *Batch) Pool() *StringCall {
+	call := &StringCall{}
+	b.add(&call.BatchCall, "pool", nil, into("pool", &call.value))
+	return call
+}
+
+// PoolConfirmedBalance queues a call to Client.PoolConfirmedBalance
+func (b *Batch) PoolConfirmedBalance() *LunaCall {
+	call := &LunaCall{}
+	b.add(&call.BatchCall, "poolConfirmedBalance", nil, into("poolConfirmedBalance", &call.value))
+	return call
+}
+
+// PoolConnectionState queues a call to Client.PoolConnectionState
+func (b *Batch) PoolConnectionState() *PoolConnectionStateCall {
+	call := &PoolConnectionStateCall{state: PoolConnectionStateClosed}
+	b.add(&call.BatchCall, "poolConnectionState", nil, into("poolConnectionState", &call.state))
+	return call
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+func TestBatchExecute(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	account := srv.NewAccount(12345)
+	srv.MineBlocks(2)
+
+	batch := srv.Client().NewBatch()
+	balance := batch.GetBalance(account.Address)
+	block := batch.GetBlockByNumber(2, true)
+	missing := batch.GetBlockByNumber(100, false)
+	blockNumber := batch.BlockNumber()
+	accounts := batch.Accounts()
+	typed := batch.GetTypedAccount(account.Address)
+
+	if _, err := balance.Result(); err != nimiqrpc.ErrBatchNotExecuted {
+		t.Fatalf("expected ErrBatchNotExecuted before Execute, got %v", err)
+	}
+
+	if err := batch.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	if luna, err := balance.Result(); err != nil || luna != 12345 {
+		t.Errorf("GetBalance: got %d, %v", luna, err)
+	}
+	if b, err := block.Result(); err != nil || b == nil || b.Number != 2 {
+		t.Errorf("GetBlockByNumber: got %+v, %v", b, err)
+	}
+	if b, err := missing.Result(); err != nil || b != nil {
+		t.Errorf("GetBlockByNumber of unknown block: got %+v, %v", b, err)
+	}
+	if n, err := blockNumber.Result(); err != nil || n != 3 {
+		t.Errorf("BlockNumber: got %d, %v", n, err)
+	}
+	if accs, err := accounts.Result(); err != nil || accs == nil {
+		t.Errorf("Accounts: got %v, %v", accs, err)
+	}
+
+	if acc, err := typed.Result(); err != nil || acc.AccountType() != nimiqrpc.AccountTypeBasic || acc.Flat().Balance != 12345 {
+		t.Errorf("GetTypedAccount: got %+v, %v", acc, err)
+	}
+
+	if err := batch.Execute(); err != nimiqrpc.ErrBatchExecuted {
+		t.Errorf("expected ErrBatchExecuted, got %v", err)
+	}
+}
+
+func TestBatchItemError(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("getBalance", func(params []json.RawMessage) (interface{}, error) {
+		return nil, &nimiqrpc.RPCError{Code: nimiqtest.CodeInternalError, Message: "boom"}
+	})
+
+	batch := srv.Client().NewBatch()
+	balance := batch.GetBalance("NQ07 0000 0000 0000 0000 0000 0000 0000 0000")
+	consensus := batch.Consensus()
+
+	if err := batch.Execute(); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	var rpcErr *nimiqrpc.RPCError
+	if _, err := balance.Result(); !errors.As(err, &rpcErr) || rpcErr.Method != "getBalance" {
+		t.Errorf("expected RPCError for getBalance, got %v", err)
+	}
+	if state, err := consensus.Result(); err != nil || state != nimiqrpc.ConsensusEstablished {
+		t.Errorf("Consensus: got %q, %v", state, err)
+	}
+}
+
+func TestBatchEmpty(t *testing.T) {
+	if err := nimiqrpc.NewClient("http://127.0.0.1:0").NewBatch().Execute(); err != nil {
+		t.Errorf("expected empty batch to be a no-op, got %v", err)
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BlockWatcherConfig configures a BlockWatcher. Zero values are replaced by sensible defaults.
+type BlockWatcherConfig struct {
+	// StartHeight is the height of the first block that is emitted. To resume after a restart,
+	// set it to the height after the last processed block. Defaults to the height after the
+	// current head of the node, so only new blocks are emitted.
+	StartHeight int
+
+	// PollInterval is the interval at which the node is polled for new blocks. Defaults to 5 seconds.
+	PollInterval time.Duration
+
+	// FullTransactions sets whether the emitted blocks include the full transactions
+	// instead of only their hashes
+	FullTransactions bool
+
+	// OnError is called with every error that occurs while polling the node. The watcher keeps
+	// polling after an error, so it survives unavailable or restarting nodes.
+	OnError func(err error)
+}
+
+// BlockWatcher polls a node for new blocks and emits every block in order. When several blocks
+// were mined between two polls, all of them are emitted, so no block is skipped.
+type BlockWatcher struct {
+	api    NimiqAPI
+	config BlockWatcherConfig
+	blocks chan Block
+
+	mu   sync.Mutex
+	next int // height of the next block to emit
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewBlockWatcher returns a new BlockWatcher that polls api for new blocks. The api is typically a
+// Client or FailoverClient. The node is polled in the background until Close is called.
+func NewBlockWatcher(api NimiqAPI, config BlockWatcherConfig) *BlockWatcher {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bw := &BlockWatcher{
+		api:    api,
+		config: config,
+		blocks: make(chan Block),
+		next:   config.StartHeight,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go bw.run(ctx)
+
+	return bw
+}
+
+// Blocks returns the channel on which the blocks are emitted. The channel is closed when
+// the watcher is closed.
+func (bw *BlockWatcher) Blocks() <-chan Block {
+	return bw.blocks
+}
+
+// Next returns the height of the next block that will be emitted. It returns 0 if the
+// watcher has not determined its start height yet.
+func (bw *BlockWatcher) Next() int {
+	bw.mu.Lock()
+	defer bw.mu.Unlock()
+	return bw.next
+}
+
+// Close stops polling and closes the channel of blocks
+func (bw *BlockWatcher) Close() {
+	bw.cancel()
+	<-bw.done
+}
+
+// run polls the node at every poll interval until ctx is done
+func (bw *BlockWatcher) run(ctx context.Context) {
+	defer close(bw.done)
+	defer close(bw.blocks)
+
+	ticker := time.NewTicker(bw.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		bw.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll emits all blocks from the next height up to the current head of the node
+func (bw *BlockWatcher) poll(ctx context.Context) {
+	head, err := bw.api.BlockNumberContext(ctx)
+	if err != nil {
+		bw.error(ctx, err)
+		return
+	}
+
+	bw.mu.Lock()
+	if bw.next <= 0 {
+		bw.next = head + 1
+	}
+	next := bw.next
+	bw.mu.Unlock()
+
+	// A node that restarted may report a lower head until it caught up again, in which case
+	// the missing blocks are emitted at a later poll
+	for ; next <= head; next++ {
+		block, err := bw.api.GetBlockByNumberContext(ctx, next, bw.config.FullTransactions)
+		if err != nil {
+			bw.error(ctx, err)
+			return
+		}
+		if block == nil {
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case bw.blocks <- *block:
+		}
+
+		bw.mu.Lock()
+		bw.next = next + 1
+		bw.mu.Unlock()
+	}
+}
+
+// error reports err to the error handler, unless the watcher is closing
+func (bw *BlockWatcher) error(ctx context.Context, err error) {
+	if ctx.Err() != nil || bw.config.OnError == nil {
+		return
+	}
+	bw.config.OnError(err)
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// expectBlocks receives blocks from bw and checks that they have the given heights
+func expectBlocks(t *testing.T, bw *nimiqrpc.BlockWatcher, from, to int) {
+	t.Helper()
+	for number := from; number <= to; number++ {
+		select {
+		case block := <-bw.Blocks():
+			if block.Number != number {
+				t.Fatalf("expected block %d, got %d", number, block.Number)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for block %d", number)
+		}
+	}
+}
+
+func TestBlockWatcher(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.MineBlocks(3)
+
+	bw := nimiqrpc.NewBlockWatcher(srv.Client(), nimiqrpc.BlockWatcherConfig{
+		StartHeight:  2,
+		PollInterval: 10 * time.Millisecond,
+	})
+	defer bw.Close()
+
+	expectBlocks(t, bw, 2, 4)
+
+	// Several blocks between polls are all emitted
+	srv.MineBlocks(5)
+	expectBlocks(t, bw, 5, 9)
+
+	if next := bw.Next(); next != 10 {
+		t.Errorf("expected next height 10, got %d", next)
+	}
+}
+
+func TestBlockWatcherNewBlocks(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.MineBlocks(3)
+
+	bw := nimiqrpc.NewBlockWatcher(srv.Client(), nimiqrpc.BlockWatcherConfig{
+		PollInterval: 10 * time.Millisecond,
+	})
+
+	for bw.Next() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	srv.MineBlock()
+	expectBlocks(t, bw, 5, 5)
+
+	bw.Close()
+	if _, ok := <-bw.Blocks(); ok {
+		t.Error("expected blocks channel to be closed after Close")
+	}
+}
+
+func TestBlockWatcherNodeRestart(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.MineBlocks(2)
+
+	var mu sync.Mutex
+	down := false
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if down {
+			return nil, &nimiqrpc.RPCError{Code: nimiqtest.CodeInternalError, Message: "node is restarting"}
+		}
+		return srv.BlockNumber(), nil
+	})
+
+	errs := make(chan error, 100)
+	bw := nimiqrpc.NewBlockWatcher(srv.Client(), nimiqrpc.BlockWatcherConfig{
+		StartHeight:  1,
+		PollInterval: 10 * time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	defer bw.Close()
+
+	expectBlocks(t, bw, 1, 3)
+
+	mu.Lock()
+	down = true
+	mu.Unlock()
+	srv.MineBlocks(2)
+
+	select {
+	case <-errs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected error while the node is down")
+	}
+
+	mu.Lock()
+	down = false
+	mu.Unlock()
+	expectBlocks(t, bw, 4, 5)
+}
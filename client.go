@@ -15,9 +15,10 @@
 package nimiqrpc
 
 import (
-	"encoding/base64"
+	"context"
 	"errors"
-	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/ybbus/jsonrpc"
 )
@@ -38,26 +39,127 @@ var (
 
 // Client contains a Nimiq RPC client
 type Client struct {
-	rpcClient jsonrpc.RPCClient
+	address      string
+	httpClient   *http.Client
+	headers      map[string]string
+	timeout      time.Duration
+	retryPolicy  *RetryPolicy
+	pollInterval time.Duration
+	backend      backend
 }
 
-// NewClient returns a new Nimiq RPC client
-func NewClient(address string) *Client {
-	return &Client{
-		rpcClient: jsonrpc.NewClient(address),
+// backend performs single attempts of RPC calls on behalf of a Client. By default
+// a Client is its own backend and sends calls to a single node over HTTP.
+type backend interface {
+	call(ctx context.Context, method string, params interface{}) (*jsonrpc.RPCResponse, error)
+	callBatch(ctx context.Context, reqs []*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error)
+}
+
+// NewClient returns a new Nimiq RPC client. The client can be configured by
+// passing one or more options, for example:
+//
+//	nimiqClient := nimiqrpc.NewClient("address.to.nimiqnode.com",
+//	  nimiqrpc.WithTimeout(10*time.Second),
+//	  nimiqrpc.WithUserAgent("my-service/1.0"),
+//	)
+func NewClient(address string, opts ...Option) *Client {
+	nc := &Client{
+		address:    address,
+		httpClient: &http.Client{},
+		headers:    make(map[string]string),
 	}
+	nc.backend = nc
+
+	for _, opt := range opts {
+		opt(nc)
+	}
+
+	return nc
 }
 
 // NewClientWithAuth returns a RPC client with the given username and password set for
-// authentication
-func NewClientWithAuth(address, username, password string) *Client {
-	authHeader := make(map[string]string)
-	authHeader["Authorization"] = fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))))
-	return &Client{
-		rpcClient: jsonrpc.NewClientWithOpts(address, &jsonrpc.RPCClientOpts{
-			CustomHeaders: authHeader,
-		}),
+// authentication. It is a shorthand for NewClient with the WithBasicAuth option.
+func NewClientWithAuth(address, username, password string, opts ...Option) *Client {
+	return NewClient(address, append([]Option{WithBasicAuth(username, password)}, opts...)...)
+}
+
+// rpcClient returns a jsonrpc.RPCClient of which all HTTP requests are bound to ctx.
+// The jsonrpc library has no notion of a context, so the context is attached to the
+// outgoing requests by the transport of a shallow copy of the configured http.Client.
+// The returned contextTransport records the error of a failed round trip.
+func (nc *Client) rpcClient(ctx context.Context) (jsonrpc.RPCClient, *contextTransport) {
+	transport := &contextTransport{
+		ctx:  ctx,
+		base: nc.httpClient.Transport,
+	}
+	httpClient := *nc.httpClient
+	httpClient.Transport = transport
+
+	return jsonrpc.NewClientWithOpts(nc.address, &jsonrpc.RPCClientOpts{
+		HTTPClient:    &httpClient,
+		CustomHeaders: nc.headers,
+	}), transport
+}
+
+// withTimeout derives a context from ctx that is bounded by the default timeout of the client
+func (nc *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if nc.timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, nc.timeout)
+}
+
+// contextTransport is a http.RoundTripper that binds every request to a context
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+	err  error
+}
+
+// RoundTrip implements http.RoundTripper
+func (ct *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := ct.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(req.WithContext(ct.ctx))
+	if err != nil {
+		ct.err = err
+	}
+	return resp, err
+}
+
+// callError returns the error that is reported for a failed call. If the context of the call
+// has ended the context error is returned. The jsonrpc library flattens errors of the HTTP
+// transport to strings, so these are restored to allow inspection with errors.Is and errors.As.
+// HTTP errors indicating an authentication problem are mapped to ErrNotAuthenticated and ErrUnauthorized.
+func callError(ctx context.Context, transport *contextTransport, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if transport.err != nil {
+		return &TransportError{msg: err.Error(), err: transport.err}
+	}
+	return httpStatusError(err)
+}
+
+// TransportError is returned when a request could not be delivered to the node, for example
+// because the connection was refused or reset. The underlying error can be retrieved with
+// errors.Unwrap.
+type TransportError struct {
+	msg string
+	err error
+}
+
+// Error implements the error interface
+func (e *TransportError) Error() string {
+	return e.msg
+}
+
+// Unwrap returns the underlying error of the HTTP transport
+func (e *TransportError) Unwrap() error {
+	return e.err
 }
 
 // Call can be used to send a JSON-RPC request by setting the method and the parameters.
@@ -69,7 +171,36 @@ func NewClientWithAuth(address, username, password string) *Client {
 // This function returns a *jsonrpc.RPCResponse. Please see the documentation for more information
 // on how to unmarshall this RPCResponse. https://godoc.org/github.com/ybbus/jsonrpc#RPCResponse
 func (nc *Client) Call(method string, params interface{}) (*jsonrpc.RPCResponse, error) {
-	return nc.rpcClient.Call(method, params)
+	return nc.CallContext(context.Background(), method, params)
+}
+
+// CallContext is like Call but uses ctx for cancellation and deadlines. When ctx is
+// cancelled or its deadline is exceeded, the context error is returned.
+// Failed calls are retried according to the RetryPolicy of the client.
+func (nc *Client) CallContext(ctx context.Context, method string, params interface{}) (rpcResp *jsonrpc.RPCResponse, err error) {
+	err = nc.retry(ctx, []string{method}, func() error {
+		rpcResp, err = nc.backend.call(ctx, method, params)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcResp, nil
+}
+
+// call does a single attempt of an RPC call over HTTP
+func (nc *Client) call(ctx context.Context, method string, params interface{}) (*jsonrpc.RPCResponse, error) {
+	ctx, cancel := nc.withTimeout(ctx)
+	defer cancel()
+
+	rpcClient, transport := nc.rpcClient(ctx)
+	rpcResp, err := rpcClient.Call(method, params)
+	if err != nil {
+		return nil, callError(ctx, transport, err)
+	}
+
+	return rpcResp, nil
 }
 
 // CallBatch invokes a list of RPCRequests in a single batch request. This function is for more
@@ -77,17 +208,50 @@ func (nc *Client) Call(method string, params interface{}) (*jsonrpc.RPCResponse,
 //
 // Most convenient is to use the following form:
 //
-//   CallBatch(
-//     NewRequest("hashrate"),
-//     NewRequest("accounts"),
-//   })
+//	CallBatch(
+//	  NewRequest("hashrate"),
+//	  NewRequest("accounts"),
+//	})
 //
 // Returns jsonrpc.RPCResponses that is of type []*jsonrpc.RPCResponse
 // - note that a list of RPCResponses can be received unordered so it can happen that: responses[i] != responses[i].ID
 // - RPCPersponses is enriched with helper functions e.g.: responses.HasError() returns  true if one of the responses holds an RPCError
 // Please see the documenation on how to handle jsonrpc.RPCResonses: https://godoc.org/github.com/ybbus/jsonrpc#RPCResponses
 func (nc *Client) CallBatch(reqs ...*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
-	return nc.rpcClient.CallBatch(jsonrpc.RPCRequests(reqs))
+	return nc.CallBatchContext(context.Background(), reqs...)
+}
+
+// CallBatchContext is like CallBatch but uses ctx for cancellation and deadlines.
+// A failed batch is only retried if all of the batched methods can be retried safely.
+func (nc *Client) CallBatchContext(ctx context.Context, reqs ...*jsonrpc.RPCRequest) (rpcResps jsonrpc.RPCResponses, err error) {
+	methods := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		methods = append(methods, req.Method)
+	}
+
+	err = nc.retry(ctx, methods, func() error {
+		rpcResps, err = nc.backend.callBatch(ctx, reqs)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rpcResps, nil
+}
+
+// callBatch does a single attempt of an RPC batch call over HTTP
+func (nc *Client) callBatch(ctx context.Context, reqs []*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
+	ctx, cancel := nc.withTimeout(ctx)
+	defer cancel()
+
+	rpcClient, transport := nc.rpcClient(ctx)
+	rpcResps, err := rpcClient.CallBatch(jsonrpc.RPCRequests(reqs))
+	if err != nil {
+		return nil, callError(ctx, transport, err)
+	}
+
+	return rpcResps, nil
 }
 
 // NewRequest returns a *jsonrpc.RPCRequest that can be used as a parameter to the CallBatch function.
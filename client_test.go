@@ -0,0 +1,147 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// blockingServer returns a test node that does not respond until the request is canceled
+// or the node is closed
+func blockingServer() *nimiqtest.Server {
+	srv := nimiqtest.NewServer()
+	srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		return &nimiqtest.Failure{Block: true}
+	})
+	return srv
+}
+
+func TestCallContextDeadline(t *testing.T) {
+	srv := blockingServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := nc.BlockNumberContext(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+func TestCallBatchContextCancel(t *testing.T) {
+	srv := blockingServer()
+	defer srv.Close()
+	nc := srv.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	_, err := nc.CallBatchContext(ctx, nimiqrpc.NewRequest("blockNumber"), nimiqrpc.NewRequest("hashrate"))
+	if err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestClientOptions(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.MineBlocks(41)
+
+	var header http.Header
+	srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		header = r.Header
+		return nil
+	})
+
+	var transportUsed bool
+	httpClient := &http.Client{
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			transportUsed = true
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+	}
+
+	nc := nimiqrpc.NewClientWithAuth(srv.URL, "user", "pass",
+		nimiqrpc.WithHTTPClient(httpClient),
+		nimiqrpc.WithUserAgent("nimiqrpc-test"),
+		nimiqrpc.WithHeader("X-Trace-Id", "abc"),
+	)
+
+	blockNumber, err := nc.BlockNumber()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blockNumber != 42 {
+		t.Errorf("expected block number 42, got %d", blockNumber)
+	}
+	if !transportUsed {
+		t.Error("custom http client was not used")
+	}
+	if ua := header.Get("User-Agent"); ua != "nimiqrpc-test" {
+		t.Errorf("unexpected User-Agent %q", ua)
+	}
+	if trace := header.Get("X-Trace-Id"); trace != "abc" {
+		t.Errorf("unexpected X-Trace-Id %q", trace)
+	}
+	if auth := header.Get("Authorization"); auth != "Basic dXNlcjpwYXNz" {
+		t.Errorf("unexpected Authorization %q", auth)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	srv := blockingServer()
+	defer srv.Close()
+	nc := srv.Client(nimiqrpc.WithTimeout(50 * time.Millisecond))
+
+	_, err := nc.BlockNumber()
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+}
+
+// roundTripperFunc implements http.RoundTripper for a function
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestGetBlockByHashMethod(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	mined := srv.MineBlock()
+
+	var method string
+	srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		method = methods[0]
+		return nil
+	})
+
+	block, err := srv.Client().GetBlockByHash(mined.Hash, false)
+	if err != nil || block.Hash != mined.Hash {
+		t.Fatalf("unexpected block %+v, %v", block, err)
+	}
+	if method != "getBlockByHash" {
+		t.Errorf("expected getBlockByHash to be called, got %s", method)
+	}
+}
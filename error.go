@@ -0,0 +1,86 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// RPCError is returned when the node responds to a call with a JSON-RPC error.
+// Use errors.As to retrieve the details of the error.
+type RPCError struct {
+	Method  string      // the method that was called
+	Code    int         // JSON-RPC error code
+	Message string      // short description of the error
+	Data    interface{} // additional information on the error, may be nil
+}
+
+// newRPCError returns an *RPCError for the given jsonrpc error
+func newRPCError(method string, err *jsonrpc.RPCError) *RPCError {
+	return &RPCError{
+		Method:  method,
+		Code:    err.Code,
+		Message: err.Message,
+		Data:    err.Data,
+	}
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("rpc call %s(): error %d: %s", e.Method, e.Code, e.Message)
+}
+
+// statusError is returned when the node responds with an HTTP status code that maps
+// to one of the errors of this package. It matches that error with errors.Is, while
+// the underlying *jsonrpc.HTTPError can still be retrieved with errors.As.
+type statusError struct {
+	target error
+	err    *jsonrpc.HTTPError
+}
+
+// Error implements the error interface
+func (e *statusError) Error() string {
+	return fmt.Sprintf("%v: %v", e.target, e.err)
+}
+
+// Is reports whether target is the error the HTTP status code maps to
+func (e *statusError) Is(target error) bool {
+	return target == e.target
+}
+
+// Unwrap returns the underlying *jsonrpc.HTTPError
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+// httpStatusError maps HTTP errors that indicate an authentication problem to
+// ErrNotAuthenticated or ErrUnauthorized. Other errors are returned as is.
+func httpStatusError(err error) error {
+	httpErr, ok := err.(*jsonrpc.HTTPError)
+	if !ok {
+		return err
+	}
+
+	switch httpErr.Code {
+	case http.StatusUnauthorized:
+		return &statusError{target: ErrNotAuthenticated, err: httpErr}
+	case http.StatusForbidden:
+		return &statusError{target: ErrUnauthorized, err: httpErr}
+	}
+	return err
+}
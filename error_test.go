@@ -0,0 +1,78 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestRPCError(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.Handle("getBalance", func(params []json.RawMessage) (interface{}, error) {
+		return nil, &nimiqrpc.RPCError{Code: nimiqtest.CodeInvalidParams, Message: "Invalid address", Data: "NQ00"}
+	})
+
+	_, err := srv.Client().GetBalance("NQ00")
+	var rpcErr *nimiqrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Fatalf("expected *RPCError, got %v", err)
+	}
+	if rpcErr.Method != "getBalance" || rpcErr.Code != nimiqtest.CodeInvalidParams || rpcErr.Message != "Invalid address" || rpcErr.Data != "NQ00" {
+		t.Errorf("unexpected error %+v", rpcErr)
+	}
+}
+
+func TestResultUnexpected(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		return "not a number", nil
+	})
+
+	_, err := srv.Client().BlockNumber()
+	if !errors.Is(err, nimiqrpc.ErrResultUnexpected) {
+		t.Fatalf("expected %v, got %v", nimiqrpc.ErrResultUnexpected, err)
+	}
+}
+
+func TestHTTPStatusError(t *testing.T) {
+	for status, expected := range map[int]error{
+		http.StatusUnauthorized: nimiqrpc.ErrNotAuthenticated,
+		http.StatusForbidden:    nimiqrpc.ErrUnauthorized,
+	} {
+		srv := nimiqtest.NewServer()
+		srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+			return &nimiqtest.Failure{Status: status}
+		})
+		_, err := srv.Client().BlockNumber()
+		srv.Close()
+
+		if !errors.Is(err, expected) {
+			t.Errorf("status %d: expected %v, got %v", status, expected, err)
+		}
+		var httpErr *jsonrpc.HTTPError
+		if !errors.As(err, &httpErr) || httpErr.Code != status {
+			t.Errorf("status %d: expected *jsonrpc.HTTPError, got %v", status, err)
+		}
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// ErrNoNodes is returned by a FailoverClient that has no nodes configured
+var ErrNoNodes = errors.New("no nodes available")
+
+var _ NimiqAPI = (*FailoverClient)(nil)
+
+// ConsensusEstablished is the consensus state of a node that is in sync with the network
+const ConsensusEstablished = "established"
+
+// FailoverConfig configures a FailoverClient. Zero values are replaced by sensible defaults.
+type FailoverConfig struct {
+	// Endpoints contains the addresses of the Nimiq nodes
+	Endpoints []string
+
+	// HealthCheckInterval is the interval at which the health of all nodes is checked.
+	// Defaults to 10 seconds.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckTimeout bounds the time of a single health check. Defaults to 5 seconds.
+	HealthCheckTimeout time.Duration
+
+	// MaxBlockLag is the number of blocks a node may lag behind the highest node before
+	// it is considered unhealthy. Defaults to 2 blocks.
+	MaxBlockLag int
+
+	// StrictHeight requires a node to be at the height of the highest node, so no lag is
+	// allowed and MaxBlockLag is ignored
+	StrictHeight bool
+
+	// MaxConsecutiveErrors is the number of consecutive failed calls or health checks after which a
+	// node is ejected. Defaults to 3.
+	MaxConsecutiveErrors int
+
+	// EjectDuration is the minimum time a node stays ejected. After this time, the node is
+	// re-admitted as soon as it passes a health check. Defaults to 30 seconds.
+	EjectDuration time.Duration
+}
+
+// NodeStatus describes the health of a single node of a FailoverClient
+type NodeStatus struct {
+	Endpoint    string
+	Consensus   string    // consensus state reported at the last health check
+	BlockNumber int       // block height reported at the last health check
+	BlockLag    int       // number of blocks behind the highest node
+	ErrorRate   float64   // moving average of the fraction of failed calls
+	Errors      int       // number of consecutive errors
+	Healthy     bool      // whether the last health check passed
+	Ejected     bool      // whether the node is ejected
+	LastCheck   time.Time // time of the last health check
+}
+
+// available reports whether calls can be routed to the node
+func (ns *NodeStatus) available(maxBlockLag int) bool {
+	return ns.Healthy && !ns.Ejected && ns.BlockLag <= maxBlockLag
+}
+
+// failoverNode is a node of a FailoverClient
+type failoverNode struct {
+	client       *Client
+	status       NodeStatus
+	ejectedUntil time.Time
+}
+
+// FailoverClient is a Nimiq RPC client that distributes calls over multiple nodes.
+// It tracks the health of every node by its consensus state, block height and error rate,
+// and routes calls to the healthiest node. Nodes that fail repeatedly are ejected and
+// re-admitted automatically once they are healthy again.
+//
+// FailoverClient embeds a Client, so it provides the same methods as Client and can
+// be used as a drop-in replacement.
+type FailoverClient struct {
+	*Client
+
+	config FailoverConfig
+	mu     sync.Mutex
+	nodes  []*failoverNode
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+// NewFailoverClient returns a new FailoverClient for the endpoints in config. The options are
+// applied to the clients of the individual nodes, except for the retry policy which is applied
+// to the FailoverClient itself, so a retried call is routed to the healthiest node again.
+//
+// The health of the nodes is checked in the background until Close is called.
+func NewFailoverClient(config FailoverConfig, opts ...Option) *FailoverClient {
+	if config.HealthCheckInterval <= 0 {
+		config.HealthCheckInterval = 10 * time.Second
+	}
+	if config.HealthCheckTimeout <= 0 {
+		config.HealthCheckTimeout = 5 * time.Second
+	}
+	if config.StrictHeight {
+		config.MaxBlockLag = 0
+	} else if config.MaxBlockLag <= 0 {
+		config.MaxBlockLag = 2
+	}
+	if config.MaxConsecutiveErrors <= 0 {
+		config.MaxConsecutiveErrors = 3
+	}
+	if config.EjectDuration <= 0 {
+		config.EjectDuration = 30 * time.Second
+	}
+
+	fc := &FailoverClient{
+		Client: NewClient("", opts...),
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	fc.Client.backend = fc
+
+	for _, endpoint := range config.Endpoints {
+		node := &failoverNode{
+			client: NewClient(endpoint, opts...),
+		}
+		node.client.retryPolicy = nil
+		node.status.Endpoint = endpoint
+		// Nodes are assumed to be healthy until the first health check proves otherwise
+		node.status.Healthy = true
+		fc.nodes = append(fc.nodes, node)
+	}
+
+	go fc.run()
+
+	return fc
+}
+
+// Close stops the background health checks
+func (fc *FailoverClient) Close() {
+	select {
+	case <-fc.stop:
+	default:
+		close(fc.stop)
+	}
+	<-fc.done
+}
+
+// Nodes returns the status of all nodes
+func (fc *FailoverClient) Nodes() []NodeStatus {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	nodes := make([]NodeStatus, 0, len(fc.nodes))
+	for _, node := range fc.nodes {
+		nodes = append(nodes, node.status)
+	}
+	return nodes
+}
+
+// run checks the health of all nodes at every health check interval
+func (fc *FailoverClient) run() {
+	defer close(fc.done)
+
+	ticker := time.NewTicker(fc.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		fc.CheckHealth(context.Background())
+
+		select {
+		case <-fc.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckHealth checks the consensus state and block height of all nodes. It is called
+// periodically in the background, but can be called to force a health check.
+func (fc *FailoverClient) CheckHealth(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, fc.config.HealthCheckTimeout)
+	defer cancel()
+
+	type result struct {
+		consensus   string
+		blockNumber int
+		err         error
+	}
+
+	results := make([]result, len(fc.nodes))
+	var wg sync.WaitGroup
+	for i, node := range fc.nodes {
+		wg.Add(1)
+		go func(i int, nc *Client) {
+			defer wg.Done()
+			res := &results[i]
+			res.consensus, res.err = nc.ConsensusContext(ctx)
+			if res.err != nil {
+				return
+			}
+			res.blockNumber, res.err = nc.BlockNumberContext(ctx)
+		}(i, node.client)
+	}
+	wg.Wait()
+
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	now := time.Now()
+	highest := 0
+	for i, node := range fc.nodes {
+		res := results[i]
+		node.status.LastCheck = now
+		node.status.Consensus = res.consensus
+		if res.err != nil {
+			node.status.Healthy = false
+			fc.record(node, res.err)
+			continue
+		}
+
+		node.status.BlockNumber = res.blockNumber
+		node.status.Healthy = res.consensus == ConsensusEstablished
+		if res.blockNumber > highest {
+			highest = res.blockNumber
+		}
+	}
+
+	for _, node := range fc.nodes {
+		node.status.BlockLag = highest - node.status.BlockNumber
+		if node.status.Ejected && node.status.Healthy && now.After(node.ejectedUntil) {
+			node.status.Ejected = false
+			node.status.Errors = 0
+		}
+	}
+}
+
+// record updates the error statistics of a node after a call. fc.mu must be held.
+func (fc *FailoverClient) record(node *failoverNode, err error) {
+	const weight = 0.1
+
+	if err == nil {
+		node.status.Errors = 0
+		node.status.ErrorRate *= 1 - weight
+		return
+	}
+
+	node.status.Errors++
+	node.status.ErrorRate = node.status.ErrorRate*(1-weight) + weight
+	if node.status.Errors >= fc.config.MaxConsecutiveErrors && !node.status.Ejected {
+		node.status.Ejected = true
+		node.ejectedUntil = time.Now().Add(fc.config.EjectDuration)
+	}
+}
+
+// candidates returns all nodes ordered from most to least preferred. Available nodes are
+// always preferred, unavailable and ejected nodes are only used as a last resort.
+func (fc *FailoverClient) candidates() []*failoverNode {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+
+	nodes := make([]*failoverNode, len(fc.nodes))
+	copy(nodes, fc.nodes)
+
+	maxBlockLag := fc.config.MaxBlockLag
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := &nodes[i].status, &nodes[j].status
+		if a.available(maxBlockLag) != b.available(maxBlockLag) {
+			return a.available(maxBlockLag)
+		}
+		if a.Ejected != b.Ejected {
+			return !a.Ejected
+		}
+		if a.BlockLag != b.BlockLag {
+			return a.BlockLag < b.BlockLag
+		}
+		return a.ErrorRate < b.ErrorRate
+	})
+
+	return nodes
+}
+
+// do sends a call to the most preferred node. If the call fails with a retryable error,
+// it fails over to the next node, unless the call is not idempotent.
+func (fc *FailoverClient) do(ctx context.Context, methods []string, fn func(nc *Client) error) (err error) {
+	nodes := fc.candidates()
+	if len(nodes) == 0 {
+		return ErrNoNodes
+	}
+
+	failover := idempotent(methods)
+	for _, node := range nodes {
+		err = fn(node.client)
+
+		fc.mu.Lock()
+		if err == nil || fc.retryable(err) {
+			fc.record(node, err)
+		}
+		fc.mu.Unlock()
+
+		if err == nil || !failover || ctx.Err() != nil || !fc.retryable(err) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// call implements backend
+func (fc *FailoverClient) call(ctx context.Context, method string, params interface{}) (rpcResp *jsonrpc.RPCResponse, err error) {
+	err = fc.do(ctx, []string{method}, func(nc *Client) error {
+		rpcResp, err = nc.backend.call(ctx, method, params)
+		return err
+	})
+	return
+}
+
+// callBatch implements backend
+func (fc *FailoverClient) callBatch(ctx context.Context, reqs []*jsonrpc.RPCRequest) (rpcResps jsonrpc.RPCResponses, err error) {
+	methods := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		methods = append(methods, req.Method)
+	}
+
+	err = fc.do(ctx, methods, func(nc *Client) error {
+		rpcResps, err = nc.backend.callBatch(ctx, reqs)
+		return err
+	})
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// testAddress is the address of the account whose balance is requested from the nodes
+const testAddress = "NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2"
+
+// nodeServer returns a test node with the given consensus state and head, on which the account
+// of testAddress has the given balance
+func nodeServer(consensus string, blockNumber int, balance nimiqrpc.Luna) *nimiqtest.Server {
+	srv := nimiqtest.NewServer()
+	srv.SetConsensus(consensus)
+	srv.MineBlocks(blockNumber - srv.BlockNumber())
+	srv.AddAccount(nimiqrpc.Account{Address: testAddress, Balance: balance})
+	return srv
+}
+
+func TestFailoverClient(t *testing.T) {
+	syncing := nodeServer("syncing", 100, 1)
+	defer syncing.Close()
+	lagging := nodeServer(nimiqrpc.ConsensusEstablished, 90, 2)
+	defer lagging.Close()
+	healthy := nodeServer(nimiqrpc.ConsensusEstablished, 100, 3)
+	down := nodeServer(nimiqrpc.ConsensusEstablished, 100, 4)
+	down.Close()
+
+	fc := nimiqrpc.NewFailoverClient(nimiqrpc.FailoverConfig{
+		Endpoints:            []string{down.URL, syncing.URL, lagging.URL, healthy.URL},
+		HealthCheckInterval:  time.Hour,
+		MaxConsecutiveErrors: 1,
+	})
+	defer fc.Close()
+	fc.CheckHealth(context.Background())
+
+	balance, err := fc.GetBalance(testAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 3 {
+		t.Errorf("expected call to be routed to the healthy node, got balance %d", balance)
+	}
+
+	nodes := fc.Nodes()
+	if !nodes[0].Ejected {
+		t.Error("expected the node that is down to be ejected")
+	}
+	if nodes[1].Healthy {
+		t.Error("expected the syncing node to be unhealthy")
+	}
+	if nodes[2].BlockLag != 10 {
+		t.Errorf("expected a block lag of 10, got %d", nodes[2].BlockLag)
+	}
+
+	// When the healthy node goes down, calls fail over to the remaining nodes
+	healthy.Close()
+	if _, err = fc.GetBalance(testAddress); err != nil {
+		t.Fatal(err)
+	}
+	if !fc.Nodes()[3].Ejected {
+		t.Error("expected the node that went down to be ejected")
+	}
+}
+
+func TestFailoverClientMaxBlockLag(t *testing.T) {
+	lagging := nodeServer(nimiqrpc.ConsensusEstablished, 99, 1)
+	defer lagging.Close()
+	syncing := nodeServer("syncing", 100, 2)
+	defer syncing.Close()
+
+	for _, test := range []struct {
+		strictHeight bool
+		balance      nimiqrpc.Luna
+	}{
+		{true, 2},  // no node is available, so the node with the lowest lag is used as a last resort
+		{false, 1}, // the default lag of 2 blocks makes the lagging node available
+	} {
+		fc := nimiqrpc.NewFailoverClient(nimiqrpc.FailoverConfig{
+			Endpoints:           []string{lagging.URL, syncing.URL},
+			HealthCheckInterval: time.Hour,
+			StrictHeight:        test.strictHeight,
+		})
+		fc.CheckHealth(context.Background())
+
+		balance, err := fc.GetBalance(testAddress)
+		if err != nil || balance != test.balance {
+			t.Errorf("StrictHeight %t: expected balance %d, got %d, %v", test.strictHeight, test.balance, balance, err)
+		}
+		fc.Close()
+	}
+}
+
+func TestFailoverClientRetryable(t *testing.T) {
+	// The node is healthy, but fails calls with an internal server error
+	failing := nodeServer(nimiqrpc.ConsensusEstablished, 100, 1)
+	failing.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		if methods[0] == "getBalance" {
+			return &nimiqtest.Failure{Status: http.StatusInternalServerError, Body: "internal server error"}
+		}
+		return nil
+	})
+	defer failing.Close()
+	healthy := nodeServer(nimiqrpc.ConsensusEstablished, 100, 2)
+	defer healthy.Close()
+
+	// An internal server error is not retryable by default, but the retry policy may classify it as retryable
+	fc := nimiqrpc.NewFailoverClient(nimiqrpc.FailoverConfig{
+		Endpoints:           []string{failing.URL, healthy.URL},
+		HealthCheckInterval: time.Hour,
+	}, nimiqrpc.WithRetryPolicy(nimiqrpc.RetryPolicy{
+		MaxAttempts: 1,
+		Retryable: func(err error) bool {
+			return true
+		},
+	}))
+	defer fc.Close()
+	fc.CheckHealth(context.Background())
+
+	balance, err := fc.GetBalance(testAddress)
+	if err != nil || balance != 2 {
+		t.Errorf("expected failover to the healthy node, got balance %d, %v", balance, err)
+	}
+
+	fc = nimiqrpc.NewFailoverClient(nimiqrpc.FailoverConfig{
+		Endpoints:           []string{failing.URL, healthy.URL},
+		HealthCheckInterval: time.Hour,
+	})
+	defer fc.Close()
+	fc.CheckHealth(context.Background())
+
+	if _, err := fc.GetBalance(testAddress); err == nil {
+		t.Error("expected internal server error without failover")
+	}
+}
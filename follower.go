@@ -0,0 +1,286 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrReorgTooDeep is reported when a chain reorganisation reverts more blocks than the reorg depth
+// of a ChainFollower. The blocks before the reorg depth that were orphaned are not reverted.
+var ErrReorgTooDeep = errors.New("chain reorganisation is deeper than the reorg depth")
+
+// Types of chain events
+const (
+	BlockApplied  ChainEventType = 0
+	BlockReverted ChainEventType = 1
+)
+
+// ChainEventType is the type of a ChainEvent
+type ChainEventType int
+
+// String returns the name of the chain event type
+func (t ChainEventType) String() string {
+	switch t {
+	case BlockApplied:
+		return "applied"
+	case BlockReverted:
+		return "reverted"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(t))
+	}
+}
+
+// ChainEvent is emitted by a ChainFollower when a block is added to or removed from the main chain
+type ChainEvent struct {
+	Type  ChainEventType
+	Block Block
+}
+
+// ChainFollowerConfig configures a ChainFollower. Zero values are replaced by sensible defaults.
+type ChainFollowerConfig struct {
+	// StartHeight is the height of the first block that is applied. To resume after a restart,
+	// set it to the height after the last processed block. Defaults to the height after the
+	// current head of the node, so only new blocks are applied.
+	StartHeight int
+
+	// PollInterval is the interval at which the node is polled for new blocks. Defaults to 5 seconds.
+	PollInterval time.Duration
+
+	// ReorgDepth is the number of most recent blocks that are tracked to detect chain
+	// reorganisations. Defaults to 10 blocks.
+	ReorgDepth int
+
+	// FullTransactions sets whether the blocks include the full transactions
+	// instead of only their hashes
+	FullTransactions bool
+
+	// OnError is called with every error that occurs while polling the node. The follower keeps
+	// polling after an error, so it survives unavailable or restarting nodes.
+	OnError func(err error)
+}
+
+// ChainFollower polls a node for new blocks and follows the main chain. It tracks the hashes of the
+// most recent blocks, and when a block it applied is orphaned by a chain reorganisation, it emits a
+// BlockReverted event for every orphaned block, newest first, followed by a BlockApplied event for
+// every block of the new main chain.
+type ChainFollower struct {
+	api    NimiqAPI
+	config ChainFollowerConfig
+	events chan ChainEvent
+
+	mu     sync.Mutex
+	next   int     // height of the next block to apply
+	recent []Block // most recently applied blocks, in ascending order
+
+	// base is the hash of the parent of the oldest tracked block, or of the next block if no block
+	// is tracked. baseApplied is set if that block was applied and has left the tracked window.
+	base        string
+	baseApplied bool
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewChainFollower returns a new ChainFollower that polls api for new blocks. The node is polled in the
+// background until Close is called.
+func NewChainFollower(api NimiqAPI, config ChainFollowerConfig) *ChainFollower {
+	if config.PollInterval <= 0 {
+		config.PollInterval = 5 * time.Second
+	}
+	if config.ReorgDepth <= 0 {
+		config.ReorgDepth = 10
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cf := &ChainFollower{
+		api:    api,
+		config: config,
+		events: make(chan ChainEvent),
+		next:   config.StartHeight,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	go cf.run(ctx)
+
+	return cf
+}
+
+// Events returns the channel on which the chain events are emitted. The channel is closed when
+// the follower is closed.
+func (cf *ChainFollower) Events() <-chan ChainEvent {
+	return cf.events
+}
+
+// Next returns the height of the next block that will be applied. It returns 0 if the
+// follower has not determined its start height yet.
+func (cf *ChainFollower) Next() int {
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+	return cf.next
+}
+
+// Close stops polling and closes the channel of events
+func (cf *ChainFollower) Close() {
+	cf.cancel()
+	<-cf.done
+}
+
+// run polls the node at every poll interval until ctx is done
+func (cf *ChainFollower) run(ctx context.Context) {
+	defer close(cf.done)
+	defer close(cf.events)
+
+	ticker := time.NewTicker(cf.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		err := cf.poll(ctx)
+		if err != nil && ctx.Err() == nil && cf.config.OnError != nil {
+			cf.config.OnError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reverts the applied blocks that are no longer part of the main chain, and applies all
+// blocks up to the current head of the node
+func (cf *ChainFollower) poll(ctx context.Context) error {
+	head, err := cf.api.BlockNumberContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	cf.mu.Lock()
+	if cf.next <= 0 {
+		cf.next = head + 1
+	}
+	cf.mu.Unlock()
+
+	// The main chain may have been replaced by a chain of the same or a lower height,
+	// which is only noticed by comparing the tip
+	for len(cf.recent) > 0 {
+		tip := cf.recent[len(cf.recent)-1]
+		block, err := cf.api.GetBlockByNumberContext(ctx, tip.Number, cf.config.FullTransactions)
+		if err != nil {
+			return err
+		}
+		if block == nil || block.Hash == tip.Hash {
+			break
+		}
+		if err := cf.revert(ctx); err != nil {
+			return err
+		}
+	}
+
+	for cf.Next() <= head {
+		block, err := cf.api.GetBlockByNumberContext(ctx, cf.Next(), cf.config.FullTransactions)
+		if err != nil {
+			return err
+		}
+		// A node that restarted may report a lower head until it caught up again
+		if block == nil {
+			return nil
+		}
+
+		if parent := cf.parentHash(); parent != "" && block.ParentHash != parent {
+			if len(cf.recent) > 0 {
+				if err := cf.revert(ctx); err != nil {
+					return err
+				}
+				continue
+			}
+
+			// The parent of the next block was orphaned as well. If it was applied, it can no longer
+			// be reverted, otherwise it precedes the start height and does not concern the follower.
+			if cf.baseApplied && cf.config.OnError != nil {
+				cf.config.OnError(fmt.Errorf("%w: orphaned block %s", ErrReorgTooDeep, parent))
+			}
+		}
+
+		if err := cf.apply(ctx, *block); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parentHash returns the hash of the block that must be the parent of the next block,
+// or an empty string if it is not known yet
+func (cf *ChainFollower) parentHash() string {
+	if len(cf.recent) > 0 {
+		return cf.recent[len(cf.recent)-1].Hash
+	}
+	return cf.base
+}
+
+// apply emits a BlockApplied event for block and tracks it
+func (cf *ChainFollower) apply(ctx context.Context, block Block) error {
+	err := cf.emit(ctx, ChainEvent{Type: BlockApplied, Block: block})
+	if err != nil {
+		return err
+	}
+
+	cf.mu.Lock()
+	defer cf.mu.Unlock()
+
+	if len(cf.recent) == 0 && block.ParentHash != cf.base {
+		cf.base, cf.baseApplied = block.ParentHash, false
+	}
+	cf.recent = append(cf.recent, block)
+	if len(cf.recent) > cf.config.ReorgDepth {
+		cf.base, cf.baseApplied = cf.recent[0].Hash, true
+		cf.recent = cf.recent[1:]
+	}
+	cf.next = block.Number + 1
+	return nil
+}
+
+// revert emits a BlockReverted event for the most recently applied block and stops tracking it
+func (cf *ChainFollower) revert(ctx context.Context) error {
+	tip := cf.recent[len(cf.recent)-1]
+	err := cf.emit(ctx, ChainEvent{Type: BlockReverted, Block: tip})
+	if err != nil {
+		return err
+	}
+
+	cf.mu.Lock()
+	cf.recent = cf.recent[:len(cf.recent)-1]
+	cf.next = tip.Number
+	cf.mu.Unlock()
+
+	return nil
+}
+
+// emit sends the event on the channel of events, unless ctx is done
+func (cf *ChainFollower) emit(ctx context.Context, event ChainEvent) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case cf.events <- event:
+		return nil
+	}
+}
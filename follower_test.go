@@ -0,0 +1,207 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// testChain is a scripted main chain that is served by a nimiqtest.Server, and can be replaced
+// by a fork to simulate chain reorganisations
+type testChain struct {
+	mu     sync.Mutex
+	blocks []nimiqrpc.Block
+}
+
+// serve registers the blockNumber and getBlockByNumber handlers of the chain
+func (c *testChain) serve(srv *nimiqtest.Server) {
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.blocks), nil
+	})
+	srv.Handle("getBlockByNumber", func(params []json.RawMessage) (interface{}, error) {
+		var number int
+		json.Unmarshal(params[0], &number)
+
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if number < 1 || number > len(c.blocks) {
+			return nil, nil
+		}
+		return c.blocks[number-1], nil
+	})
+}
+
+// extend adds n blocks of the given fork to the chain, after the block at the given height
+func (c *testChain) extend(height, n int, fork string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.blocks = c.blocks[:height]
+	for i := 0; i < n; i++ {
+		block := nimiqrpc.Block{
+			Number:       len(c.blocks) + 1,
+			Hash:         fmt.Sprintf("%s%d", fork, len(c.blocks)+1),
+			Transactions: json.RawMessage("[]"),
+		}
+		if len(c.blocks) > 0 {
+			block.ParentHash = c.blocks[len(c.blocks)-1].Hash
+		}
+		c.blocks = append(c.blocks, block)
+	}
+}
+
+// expectEvent receives an event from cf and checks its type and block hash
+func expectEvent(t *testing.T, cf *nimiqrpc.ChainFollower, eventType nimiqrpc.ChainEventType, hash string) {
+	t.Helper()
+	select {
+	case event := <-cf.Events():
+		if event.Type != eventType || event.Block.Hash != hash {
+			t.Fatalf("expected %s %s, got %s %s", eventType, hash, event.Type, event.Block.Hash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timeout waiting for %s %s", eventType, hash)
+	}
+}
+
+func TestChainFollowerReorg(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	chain := &testChain{}
+	chain.serve(srv)
+	chain.extend(0, 3, "a")
+
+	cf := nimiqrpc.NewChainFollower(srv.Client(), nimiqrpc.ChainFollowerConfig{
+		StartHeight:  1,
+		PollInterval: 10 * time.Millisecond,
+	})
+	defer cf.Close()
+
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "a1")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "a2")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "a3")
+
+	// A longer fork replaces the last two blocks
+	chain.extend(1, 3, "b")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "a3")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "a2")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "b2")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "b3")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "b4")
+
+	// A fork of the same height replaces the tip
+	chain.extend(3, 1, "c")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "b4")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "c4")
+
+	if next := cf.Next(); next != 5 {
+		t.Errorf("expected next height 5, got %d", next)
+	}
+}
+
+func TestChainFollowerReorgTooDeep(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	chain := &testChain{}
+	chain.serve(srv)
+	chain.extend(0, 4, "a")
+
+	errs := make(chan error, 10)
+	cf := nimiqrpc.NewChainFollower(srv.Client(), nimiqrpc.ChainFollowerConfig{
+		StartHeight:  1,
+		PollInterval: 10 * time.Millisecond,
+		ReorgDepth:   2,
+		OnError: func(err error) {
+			errs <- err
+		},
+	})
+	defer cf.Close()
+
+	for _, hash := range []string{"a1", "a2", "a3", "a4"} {
+		expectEvent(t, cf, nimiqrpc.BlockApplied, hash)
+	}
+
+	chain.extend(1, 4, "b")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "a4")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "a3")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "b3")
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, nimiqrpc.ErrReorgTooDeep) {
+			t.Errorf("expected ErrReorgTooDeep, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected ErrReorgTooDeep")
+	}
+}
+
+func TestChainFollowerShallowReorgAfterStart(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	chain := &testChain{}
+	chain.serve(srv)
+	chain.extend(0, 3, "a")
+
+	errs := make(chan error, 10)
+	cf := nimiqrpc.NewChainFollower(srv.Client(), nimiqrpc.ChainFollowerConfig{
+		StartHeight:  3,
+		PollInterval: 10 * time.Millisecond,
+		OnError: func(err error) {
+			errs <- err
+		},
+	})
+	defer cf.Close()
+
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "a3")
+
+	// The only applied block is replaced, and its parent is still checked
+	chain.extend(2, 1, "b")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "a3")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "b3")
+
+	// A fork that also replaces the block before the start height only reverts the applied blocks
+	chain.extend(1, 3, "c")
+	expectEvent(t, cf, nimiqrpc.BlockReverted, "b3")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "c3")
+	expectEvent(t, cf, nimiqrpc.BlockApplied, "c4")
+
+	select {
+	case err := <-errs:
+		t.Errorf("unexpected error %v", err)
+	default:
+	}
+}
+
+func TestChainEventTypeString(t *testing.T) {
+	if s := nimiqrpc.BlockReverted.String(); s != "reverted" {
+		t.Errorf("expected reverted, got %s", s)
+	}
+	if s := nimiqrpc.ChainEventType(5).String(); s != "unknown(5)" {
+		t.Errorf("expected unknown(5), got %s", s)
+	}
+}
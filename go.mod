@@ -3,6 +3,8 @@ module github.com/redmaner/go-nimiq-rpc
 go 1.13
 
 require (
+	github.com/gorilla/websocket v1.4.2
 	github.com/onsi/gomega v1.8.1 // indirect
 	github.com/ybbus/jsonrpc v2.1.2+incompatible
+	golang.org/x/crypto v0.0.0-20200622213623-75b288015ac9
 )
@@ -0,0 +1,146 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Hash algorithms of the hash root of an HTLC
+const (
+	HashAlgorithmBlake2b HashAlgorithm = 1
+	HashAlgorithmArgon2d HashAlgorithm = 2
+	HashAlgorithmSHA256  HashAlgorithm = 3
+	HashAlgorithmSHA512  HashAlgorithm = 4
+)
+
+// Types of the proofs that resolve an HTLC
+const (
+	htlcProofRegularTransfer = 1
+	htlcProofEarlyResolve    = 2
+	htlcProofTimeoutResolve  = 3
+)
+
+// HashAlgorithm is the algorithm that is used to hash the pre-image of an HTLC
+type HashAlgorithm uint8
+
+// Size returns the size of the hashes of the algorithm in bytes, or 0 for unknown algorithms
+func (a HashAlgorithm) Size() int {
+	switch a {
+	case HashAlgorithmBlake2b, HashAlgorithmArgon2d, HashAlgorithmSHA256:
+		return 32
+	case HashAlgorithmSHA512:
+		return 64
+	default:
+		return 0
+	}
+}
+
+// Hash returns the hash of data. Argon2d is not supported.
+func (a HashAlgorithm) Hash(data []byte) ([]byte, error) {
+	switch a {
+	case HashAlgorithmBlake2b:
+		hash := blake2b.Sum256(data)
+		return hash[:], nil
+	case HashAlgorithmSHA256:
+		hash := sha256.Sum256(data)
+		return hash[:], nil
+	case HashAlgorithmSHA512:
+		hash := sha512.Sum512(data)
+		return hash[:], nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported hash algorithm %d", ErrInvalidTransaction, a)
+	}
+}
+
+// HTLC holds the parameters of a hashed time-locked contract. The funds of the contract can be
+// transferred to the recipient with the pre-image of the hash root, or back to the sender after the timeout.
+//
+// The hash root is the result of hashing the pre-image HashCount times, so the funds can be released in
+// HashCount parts by revealing the intermediate hashes.
+type HTLC struct {
+	Sender        Address // address that can resolve the contract after the timeout
+	Recipient     Address // address that can redeem the funds with the pre-image
+	HashAlgorithm HashAlgorithm
+	HashRoot      []byte
+	HashCount     uint8
+	Timeout       uint32 // height of the block at which the contract times out
+}
+
+// Data returns the data of the transaction that creates the HTLC
+func (h HTLC) Data() ([]byte, error) {
+	switch {
+	case h.HashAlgorithm.Size() == 0:
+		return nil, fmt.Errorf("%w: unknown hash algorithm %d", ErrInvalidTransaction, h.HashAlgorithm)
+	case len(h.HashRoot) != h.HashAlgorithm.Size():
+		return nil, fmt.Errorf("%w: hash root has %d bytes instead of %d", ErrInvalidTransaction, len(h.HashRoot), h.HashAlgorithm.Size())
+	case h.HashCount == 0:
+		return nil, fmt.Errorf("%w: hash count must be at least 1", ErrInvalidTransaction)
+	}
+
+	data := make([]byte, 0, 2*len(Address{})+2+len(h.HashRoot)+4)
+	data = append(data, h.Sender[:]...)
+	data = append(data, h.Recipient[:]...)
+	data = append(data, byte(h.HashAlgorithm))
+	data = append(data, h.HashRoot...)
+	data = append(data, h.HashCount)
+	return appendUint32(data, h.Timeout), nil
+}
+
+// OutgoingTransaction returns the transaction that creates the HTLC, funded with value by from.
+// See TransactionFlagContractCreation for how to send it.
+func (h HTLC) OutgoingTransaction(from Address, value, fee Luna) (OutgoingTransaction, error) {
+	data, err := h.Data()
+	if err != nil {
+		return OutgoingTransaction{}, err
+	}
+
+	return contractCreation(AccountTypeHTLC, from, value, fee, data), nil
+}
+
+// HTLCRegularTransferProof returns the proof of a transaction that transfers funds of an HTLC to its
+// recipient. The pre-image hashed hashDepth times must result in the hash root of the contract, and
+// recipientProof is the signature proof of the transaction by the recipient.
+func HTLCRegularTransferProof(algorithm HashAlgorithm, hashDepth uint8, hashRoot, preImage, recipientProof []byte) ([]byte, error) {
+	switch {
+	case algorithm.Size() == 0:
+		return nil, fmt.Errorf("%w: unknown hash algorithm %d", ErrInvalidTransaction, algorithm)
+	case len(hashRoot) != algorithm.Size() || len(preImage) != algorithm.Size():
+		return nil, fmt.Errorf("%w: hash root and pre-image must have %d bytes", ErrInvalidTransaction, algorithm.Size())
+	}
+
+	proof := []byte{htlcProofRegularTransfer, byte(algorithm), hashDepth}
+	proof = append(proof, hashRoot...)
+	proof = append(proof, preImage...)
+	return append(proof, recipientProof...), nil
+}
+
+// HTLCEarlyResolveProof returns the proof of a transaction that resolves an HTLC before its timeout,
+// which requires the signature proofs of the transaction by both the recipient and the sender
+func HTLCEarlyResolveProof(recipientProof, senderProof []byte) []byte {
+	proof := []byte{htlcProofEarlyResolve}
+	proof = append(proof, recipientProof...)
+	return append(proof, senderProof...)
+}
+
+// HTLCTimeoutResolveProof returns the proof of a transaction that transfers the funds of an HTLC back
+// to its sender after the timeout, where senderProof is the signature proof of the transaction by the sender
+func HTLCTimeoutResolveProof(senderProof []byte) []byte {
+	return append([]byte{htlcProofTimeoutResolve}, senderProof...)
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestHTLCCreation(t *testing.T) {
+	sender := testKeyPair(t)
+	recipient := testKeyPairOf(t, 1)
+
+	preImage := bytes.Repeat([]byte{0xab}, 32)
+	hashRoot, err := HashAlgorithmSHA256.Hash(preImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	htlc := HTLC{
+		Sender:        sender.Address(),
+		Recipient:     recipient.Address(),
+		HashAlgorithm: HashAlgorithmSHA256,
+		HashRoot:      hashRoot,
+		HashCount:     1,
+		Timeout:       2000,
+	}
+	data, err := htlc.Data()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := sender.Address().Hex() + recipient.Address().Hex() + "03" + hex.EncodeToString(hashRoot) + "01" + "000007d0"
+	if hex.EncodeToString(data) != expected {
+		t.Errorf("unexpected creation data %x", data)
+	}
+
+	trn, err := htlc.OutgoingTransaction(sender.Address(), 100000, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw, err := NewRawTransaction(trn, 1000, NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.RecipientType != AccountTypeHTLC || raw.Flags != TransactionFlagContractCreation {
+		t.Errorf("unexpected transaction %+v", raw)
+	}
+	if raw.Recipient.IsZero() || raw.Recipient != raw.ContractCreationAddress() {
+		t.Errorf("expected contract address as recipient, got %s", raw.Recipient)
+	}
+
+	if err := raw.Sign(sender); err != nil {
+		t.Fatal(err)
+	}
+	if raw.IsBasic() {
+		t.Error("expected extended transaction")
+	}
+	signed, err := raw.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeRawTransaction(signed)
+	if err != nil || decoded.Recipient != decoded.ContractCreationAddress() || decoded.Verify() != nil {
+		t.Errorf("unexpected decoded transaction %+v, %v", decoded, err)
+	}
+
+	// the placeholder recipient must not reach the node, which would pay the zero address
+	nc := NewClient("http://127.0.0.1:1")
+	if _, err := nc.SendTransaction(trn); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("SendTransaction: expected ErrInvalidTransaction, got %v", err)
+	}
+	if _, err := nc.CreateRawTransaction(trn); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("CreateRawTransaction: expected ErrInvalidTransaction, got %v", err)
+	}
+	trn.To = ""
+	if _, err := nc.SendTransaction(trn); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("SendTransaction without recipient: expected ErrInvalidTransaction, got %v", err)
+	}
+
+	for _, invalid := range []HTLC{
+		{HashAlgorithm: 5, HashRoot: hashRoot, HashCount: 1},
+		{HashAlgorithm: HashAlgorithmSHA512, HashRoot: hashRoot, HashCount: 1},
+		{HashAlgorithm: HashAlgorithmSHA256, HashRoot: hashRoot},
+	} {
+		if _, err := invalid.OutgoingTransaction(sender.Address(), 1, 0); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("expected ErrInvalidTransaction for %+v, got %v", invalid, err)
+		}
+	}
+}
+
+func TestHTLCProofs(t *testing.T) {
+	sender := testKeyPair(t)
+	recipient := testKeyPairOf(t, 1)
+	contract := testKeyPairOf(t, 2).Address()
+
+	redeem := &RawTransaction{
+		Sender:        contract,
+		SenderType:    AccountTypeHTLC,
+		Recipient:     recipient.Address(),
+		RecipientType: AccountTypeBasic,
+		Value:         100000,
+		NetworkID:     NetworkIDTest,
+	}
+
+	preImage := bytes.Repeat([]byte{0xab}, 32)
+	hashRoot, _ := HashAlgorithmBlake2b.Hash(preImage)
+	recipientProof := redeem.SignatureProof(recipient)
+
+	proof, err := HTLCRegularTransferProof(HashAlgorithmBlake2b, 1, hashRoot, preImage, recipientProof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append(append(append([]byte{1, 1, 1}, hashRoot...), preImage...), recipientProof...)
+	if !bytes.Equal(proof, expected) {
+		t.Errorf("unexpected regular transfer proof %x", proof)
+	}
+	if _, err := HTLCRegularTransferProof(HashAlgorithmSHA512, 1, hashRoot, preImage, recipientProof); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for hash root of wrong size, got %v", err)
+	}
+
+	senderProof := redeem.SignatureProof(sender)
+	if proof := HTLCEarlyResolveProof(recipientProof, senderProof); !bytes.Equal(proof, append(append([]byte{2}, recipientProof...), senderProof...)) {
+		t.Errorf("unexpected early resolve proof %x", proof)
+	}
+	if proof := HTLCTimeoutResolveProof(senderProof); !bytes.Equal(proof, append([]byte{3}, senderProof...)) {
+		t.Errorf("unexpected timeout resolve proof %x", proof)
+	}
+
+	redeem.Proof = HTLCTimeoutResolveProof(senderProof)
+	serialized, err := redeem.Serialize()
+	if err != nil || serialized[0] != transactionFormatExtended {
+		t.Errorf("expected extended transaction, got %x, %v", serialized, err)
+	}
+
+	if _, err := HashAlgorithmArgon2d.Hash(preImage); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for Argon2d, got %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command apigen generates the NimiqAPI interface and the nimiqrpctest.Mock from the
+// methods of Client that are declared in api.go. It is run by go generate from the
+// root of the module.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+const header = `// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by apigen from api.go. DO NOT EDIT.
+
+`
+
+// method describes an exported method of Client
+type method struct {
+	name    string
+	decl    *ast.FuncDecl
+	context bool // whether the method is the context-aware variant of another method
+}
+
+func main() {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "api.go", nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var methods []method
+	names := make(map[string]bool)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || !fn.Name.IsExported() {
+			continue
+		}
+		star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok || star.X.(*ast.Ident).Name != "Client" {
+			continue
+		}
+		methods = append(methods, method{name: fn.Name.Name, decl: fn})
+		names[fn.Name.Name] = true
+	}
+	for i := range methods {
+		base := strings.TrimSuffix(methods[i].name, "Context")
+		methods[i].context = base != methods[i].name && names[base]
+	}
+
+	write("nimiqapi.go", generateInterface(fset, methods))
+	write("nimiqrpctest/mock.go", generateMock(fset, methods))
+}
+
+// write formats and writes the generated source to path
+func write(path string, src []byte) {
+	formatted, err := format.Source(src)
+	if err != nil {
+		log.Fatalf("%s: %v\n%s", path, err, src)
+	}
+	if err := ioutil.WriteFile(path, formatted, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generateInterface generates the NimiqAPI interface
+func generateInterface(fset *token.FileSet, methods []method) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package nimiqrpc\n\n")
+	buf.WriteString("import \"context\"\n\n")
+	buf.WriteString("// NimiqAPI is the interface of the Nimiq RPC methods implemented by Client.\n")
+	buf.WriteString("// Code that depends on NimiqAPI instead of *Client can be tested with\n")
+	buf.WriteString("// the scriptable implementation in the nimiqrpctest package.\n")
+	buf.WriteString("type NimiqAPI interface {\n")
+	for _, m := range methods {
+		fmt.Fprintf(&buf, "\t%s%s\n", m.name, signature(fset, m.decl.Type, false))
+	}
+	buf.WriteString("}\n\n")
+	buf.WriteString("var _ NimiqAPI = (*Client)(nil)\n")
+	return buf.Bytes()
+}
+
+// generateMock generates the Mock implementation of NimiqAPI
+func generateMock(fset *token.FileSet, methods []method) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	buf.WriteString("package nimiqrpctest\n\n")
+	buf.WriteString("import (\n\t\"context\"\n\t\"fmt\"\n\n\t\"github.com/redmaner/go-nimiq-rpc\"\n)\n\n")
+	buf.WriteString("var _ nimiqrpc.NimiqAPI = (*Mock)(nil)\n\n")
+
+	buf.WriteString("// Mock is a scriptable implementation of nimiqrpc.NimiqAPI. The behaviour of a method\n")
+	buf.WriteString("// is scripted by setting the corresponding function field. Calling a method of which the\n")
+	buf.WriteString("// function is not set returns an error that matches ErrNotScripted.\n")
+	buf.WriteString("//\n// The methods without a context call the function of their context-aware variant\n")
+	buf.WriteString("// with context.Background().\n")
+	buf.WriteString("type Mock struct {\n\trecorder\n\n")
+	for _, m := range methods {
+		if hasVariant(m, methods) {
+			continue
+		}
+		fmt.Fprintf(&buf, "\t%sFunc func%s\n", strings.TrimSuffix(m.name, "Context"), signature(fset, m.decl.Type, true))
+	}
+	buf.WriteString("}\n")
+
+	for _, m := range methods {
+		params := m.decl.Type.Params.List
+		fmt.Fprintf(&buf, "\n// %s implements nimiqrpc.NimiqAPI\n", m.name)
+		fmt.Fprintf(&buf, "func (m *Mock) %s%s {\n", m.name, signature(fset, m.decl.Type, true))
+
+		if hasVariant(m, methods) {
+			args := append([]string{"context.Background()"}, arguments(params)...)
+			fmt.Fprintf(&buf, "\treturn m.%sContext(%s)\n}\n", m.name, strings.Join(args, ", "))
+			continue
+		}
+
+		name := strings.TrimSuffix(m.name, "Context")
+		args := arguments(params)
+		var recorded []string
+		for i, arg := range args {
+			if m.context && i == 0 {
+				continue
+			}
+			recorded = append(recorded, strings.TrimSuffix(arg, "..."))
+		}
+		if len(recorded) == 0 {
+			fmt.Fprintf(&buf, "\tm.record(%q, nil)\n", name)
+		} else {
+			fmt.Fprintf(&buf, "\tm.record(%q, []interface{}{%s})\n", name, strings.Join(recorded, ", "))
+		}
+		fmt.Fprintf(&buf, "\tif m.%sFunc == nil {\n", name)
+		fmt.Fprintf(&buf, "\t\terr = fmt.Errorf(\"%%w: %s\", ErrNotScripted)\n\t\treturn\n\t}\n", name)
+		fmt.Fprintf(&buf, "\treturn m.%sFunc(%s)\n}\n", name, strings.Join(args, ", "))
+	}
+
+	return buf.Bytes()
+}
+
+// hasVariant reports whether m has a context-aware variant
+func hasVariant(m method, methods []method) bool {
+	for _, other := range methods {
+		if other.name == m.name+"Context" {
+			return true
+		}
+	}
+	return false
+}
+
+// arguments returns the names of the parameters as arguments of a call
+func arguments(params []*ast.Field) []string {
+	var args []string
+	for _, field := range params {
+		_, variadic := field.Type.(*ast.Ellipsis)
+		for _, name := range field.Names {
+			if variadic {
+				args = append(args, name.Name+"...")
+				continue
+			}
+			args = append(args, name.Name)
+		}
+	}
+	return args
+}
+
+// signature prints the parameters and results of a function type. If qualify is true,
+// the exported types of package nimiqrpc are qualified with the package name.
+func signature(fset *token.FileSet, fn *ast.FuncType, qualify bool) string {
+	if qualify {
+		fn = qualifyTypes(fn)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, fn); err != nil {
+		log.Fatal(err)
+	}
+	return strings.TrimPrefix(buf.String(), "func")
+}
+
+// qualifyTypes returns a copy of fn with all exported identifiers, which refer to
+// types of package nimiqrpc, qualified with the package name
+func qualifyTypes(fn *ast.FuncType) *ast.FuncType {
+	var qualify func(expr ast.Expr) ast.Expr
+	qualify = func(expr ast.Expr) ast.Expr {
+		switch t := expr.(type) {
+		case *ast.Ident:
+			if t.IsExported() {
+				return &ast.SelectorExpr{X: ast.NewIdent("nimiqrpc"), Sel: ast.NewIdent(t.Name)}
+			}
+		case *ast.StarExpr:
+			return &ast.StarExpr{X: qualify(t.X)}
+		case *ast.ArrayType:
+			return &ast.ArrayType{Len: t.Len, Elt: qualify(t.Elt)}
+		case *ast.Ellipsis:
+			return &ast.Ellipsis{Elt: qualify(t.Elt)}
+		case *ast.MapType:
+			return &ast.MapType{Key: qualify(t.Key), Value: qualify(t.Value)}
+		}
+		return expr
+	}
+
+	fields := func(list *ast.FieldList) *ast.FieldList {
+		if list == nil {
+			return nil
+		}
+		copied := &ast.FieldList{}
+		for _, field := range list.List {
+			copied.List = append(copied.List, &ast.Field{Names: field.Names, Type: qualify(field.Type)})
+		}
+		return copied
+	}
+
+	return &ast.FuncType{Params: fields(fn.Params), Results: fields(fn.Results)}
+}
@@ -0,0 +1,118 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrInvalidKey is returned when a private or public key cannot be parsed
+var ErrInvalidKey = errors.New("invalid key")
+
+// KeyPair is an Ed25519 key pair of an account. The private key is kept in the process,
+// so transactions can be signed without sending the key to a node.
+type KeyPair struct {
+	privateKey ed25519.PrivateKey
+}
+
+// GenerateKeyPair generates a new key pair from a cryptographically secure random source
+func GenerateKeyPair() (*KeyPair, error) {
+	return GenerateKeyPairFrom(rand.Reader)
+}
+
+// GenerateKeyPairFrom generates a new key pair from the given source of randomness
+func GenerateKeyPairFrom(random io.Reader) (*KeyPair, error) {
+	seed := make([]byte, ed25519.SeedSize)
+	_, err := io.ReadFull(random, seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyPair{
+		privateKey: ed25519.NewKeyFromSeed(seed),
+	}, nil
+}
+
+// ParsePrivateKey returns the key pair of a hex-encoded 32 byte private key, as it is
+// exported by PrivateKey and by the node
+func ParsePrivateKey(privateKey string) (*KeyPair, error) {
+	seed, err := hex.DecodeString(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%w: private key has %d bytes instead of %d", ErrInvalidKey, len(seed), ed25519.SeedSize)
+	}
+
+	return &KeyPair{
+		privateKey: ed25519.NewKeyFromSeed(seed),
+	}, nil
+}
+
+// PrivateKey returns the hex-encoded private key
+func (kp *KeyPair) PrivateKey() string {
+	return hex.EncodeToString(kp.privateKey.Seed())
+}
+
+// PublicKey returns the public key
+func (kp *KeyPair) PublicKey() ed25519.PublicKey {
+	return kp.privateKey.Public().(ed25519.PublicKey)
+}
+
+// Address returns the address of the account of the key pair
+func (kp *KeyPair) Address() Address {
+	return AddressFromPublicKey(kp.PublicKey())
+}
+
+// Sign returns the Ed25519 signature of message
+func (kp *KeyPair) Sign(message []byte) []byte {
+	return ed25519.Sign(kp.privateKey, message)
+}
+
+// Wallet returns the wallet of the key pair, including the private key
+func (kp *KeyPair) Wallet() Wallet {
+	address := kp.Address()
+	return Wallet{
+		ID:         address.Hex(),
+		Address:    address.String(),
+		PublicKey:  hex.EncodeToString(kp.PublicKey()),
+		PrivateKey: kp.PrivateKey(),
+	}
+}
+
+// KeyPair returns the key pair of the private key of the wallet
+func (w Wallet) KeyPair() (*KeyPair, error) {
+	if w.PrivateKey == "" {
+		return nil, fmt.Errorf("%w: wallet has no private key", ErrInvalidKey)
+	}
+	return ParsePrivateKey(w.PrivateKey)
+}
+
+// AddressFromPublicKey returns the address of an Ed25519 public key, which consists of the
+// first 20 bytes of the Blake2b hash of the key
+func AddressFromPublicKey(publicKey ed25519.PublicKey) Address {
+	hash := blake2b.Sum256(publicKey)
+
+	var address Address
+	copy(address[:], hash[:])
+	return address
+}
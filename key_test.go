@@ -0,0 +1,89 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestParsePrivateKey(t *testing.T) {
+	// Test vector 1 of RFC 8032
+	const (
+		privateKey = "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"
+		publicKey  = "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a"
+		id         = "7849ac3049680be1ef762efe0d36e01733c3464e"
+	)
+
+	kp, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp.PrivateKey() != privateKey {
+		t.Errorf("expected private key %s, got %s", privateKey, kp.PrivateKey())
+	}
+
+	wallet := kp.Wallet()
+	if wallet.PublicKey != publicKey || wallet.ID != id || wallet.Address != MustParseAddress(id).String() || wallet.PrivateKey != privateKey {
+		t.Errorf("unexpected wallet %+v", wallet)
+	}
+
+	imported, err := wallet.KeyPair()
+	if err != nil || imported.Address() != kp.Address() {
+		t.Errorf("expected imported key pair of %s, got %v", kp.Address(), err)
+	}
+
+	for _, invalid := range []string{"", "9d61", privateKey + "00", "zz" + privateKey[2:]} {
+		if _, err := ParsePrivateKey(invalid); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("ParsePrivateKey(%q): expected ErrInvalidKey, got %v", invalid, err)
+		}
+	}
+	if _, err := (Wallet{ID: id}).KeyPair(); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("expected ErrInvalidKey for wallet without private key, got %v", err)
+	}
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	kp, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kp.Address() == other.Address() {
+		t.Error("expected different addresses of generated key pairs")
+	}
+
+	message := []byte("nimiq")
+	if !ed25519.Verify(kp.PublicKey(), message, kp.Sign(message)) {
+		t.Error("expected valid signature")
+	}
+
+	seeded, err := GenerateKeyPairFrom(bytes.NewReader(make([]byte, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seeded.PrivateKey() != hex.EncodeToString(make([]byte, 32)) {
+		t.Errorf("unexpected private key %s", seeded.PrivateKey())
+	}
+	if _, err := GenerateKeyPairFrom(bytes.NewReader(nil)); err == nil {
+		t.Error("expected error for exhausted source of randomness")
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by apigen from api.go. DO NOT EDIT.
+
+package nimiqrpc
+
+import "context"
+
+// NimiqAPI is the interface of the Nimiq RPC methods implemented by Client.
+// Code that depends on NimiqAPI instead of *Client can be tested with
+// the scriptable implementation in the nimiqrpctest package.
+type NimiqAPI interface {
+	Accounts() (accounts []Account, err error)
+	AccountsContext(ctx context.Context) (accounts []Account, err error)
+	BlockNumber() (blockHeight int, err error)
+	BlockNumberContext(ctx context.Context) (blockHeight int, err error)
+	Consensus() (consensus string, err error)
+	ConsensusContext(ctx context.Context) (consensus string, err error)
+	Constant(constant string) (value int, err error)
+	ConstantContext(ctx context.Context, constant string) (value int, err error)
+	CreateAccount() (wallet *Wallet, err error)
+	CreateAccountContext(ctx context.Context) (wallet *Wallet, err error)
+	CreateRawTransaction(trn OutgoingTransaction) (transactionHex string, err error)
+	CreateRawTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHex string, err error)
+	DisconnectPool() (err error)
+	DisconnectPoolContext(ctx context.Context) (err error)
+	GetAccount(address string) (account *Account, err error)
+	GetAccountContext(ctx context.Context, address string) (account *Account, err error)
+	GetTypedAccount(address string) (account TypedAccount, err error)
+	GetTypedAccountContext(ctx context.Context, address string) (account TypedAccount, err error)
+	GetBalance(address string) (balance Luna, err error)
+	GetBalanceContext(ctx context.Context, address string) (balance Luna, err error)
+	GetBlockByHash(blockHash string, fullTransactions bool) (block *Block, err error)
+	GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *Block, err error)
+	GetBlockByNumber(blockNumber int, fullTransactions bool) (block *Block, err error)
+	GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *Block, err error)
+	GetBlockTemplate(params ...interface{}) (template *BlockTemplate, err error)
+	GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *BlockTemplate, err error)
+	GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error)
+	GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error)
+	GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error)
+	GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error)
+	GetRawTransactionInfo(transactionHex string) (info *RawTransactionInfo, err error)
+	GetRawTransactionInfoContext(ctx context.Context, transactionHex string) (info *RawTransactionInfo, err error)
+	GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *Transaction, err error)
+	GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *Transaction, err error)
+	GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *Transaction, err error)
+	GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *Transaction, err error)
+	GetTransactionByHash(transactionHash string) (transaction *Transaction, err error)
+	GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *Transaction, err error)
+	GetTransactionReceipt(transactionHash string) (transactionReceipt *TransactionReceipt, err error)
+	GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *TransactionReceipt, err error)
+	GetTransactionsByAddress(address string, maxEntries int) (transactions []Transaction, err error)
+	GetTransactionsByAddressContext(ctx context.Context, address string, maxEntries int) (transactions []Transaction, err error)
+	GetWork(params ...interface{}) (work *Work, err error)
+	GetWorkContext(ctx context.Context, params ...interface{}) (work *Work, err error)
+	Hashrate() (hashrate float64, err error)
+	HashrateContext(ctx context.Context) (hashrate float64, err error)
+	Log(tag string, level LogLevel) (succes bool, err error)
+	LogContext(ctx context.Context, tag string, level LogLevel) (succes bool, err error)
+	Mempool() (mempool *Mempool, err error)
+	MempoolContext(ctx context.Context) (mempool *Mempool, err error)
+	MempoolContent(includeTransactions bool) (content *MempoolContent, err error)
+	MempoolContentContext(ctx context.Context, includeTransactions bool) (content *MempoolContent, err error)
+	MinFeePerByte() (fee Luna, err error)
+	MinFeePerByteContext(ctx context.Context) (fee Luna, err error)
+	MinerAddress() (address string, err error)
+	MinerAddressContext(ctx context.Context) (address string, err error)
+	MinerThreads() (threads int, err error)
+	MinerThreadsContext(ctx context.Context) (threads int, err error)
+	Mining() (status bool, err error)
+	MiningContext(ctx context.Context) (status bool, err error)
+	PeerCount() (peers int, err error)
+	PeerCountContext(ctx context.Context) (peers int, err error)
+	PeerList() (peers []Peer, err error)
+	PeerListContext(ctx context.Context) (peers []Peer, err error)
+	PeerState(peerAddress string, update ...string) (peer *Peer, err error)
+	PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *Peer, err error)
+	Pool() (pool string, err error)
+	PoolContext(ctx context.Context) (pool string, err error)
+	PoolConfirmedBalance() (balance Luna, err error)
+	PoolConfirmedBalanceContext(ctx context.Context) (balance Luna, err error)
+	PoolConnectionState() (state PoolConnectionState, err error)
+	PoolConnectionStateContext(ctx context.Context) (state PoolConnectionState, err error)
+	ResetConstant(constant string) (value int, err error)
+	ResetConstantContext(ctx context.Context, constant string) (value int, err error)
+	SendRawTransaction(signedTransaction string) (transactionHash string, err error)
+	SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error)
+	SendTransaction(trn OutgoingTransaction) (transactionHash string, err error)
+	SendTransactionContext(ctx context.Context, trn OutgoingTransaction) (transactionHash string, err error)
+	SetConstant(constant string, value int) (newValue int, err error)
+	SetConstantContext(ctx context.Context, constant string, value int) (newValue int, err error)
+	SetMinFeePerByte(fee Luna) (newFee Luna, err error)
+	SetMinFeePerByteContext(ctx context.Context, fee Luna) (newFee Luna, err error)
+	SetMinerThreads(threads int) (newThreads int, err error)
+	SetMinerThreadsContext(ctx context.Context, threads int) (newThreads int, err error)
+	SetMining(enabled bool) (status bool, err error)
+	SetMiningContext(ctx context.Context, enabled bool) (status bool, err error)
+	SetPool(pool string) (newPool string, err error)
+	SetPoolContext(ctx context.Context, pool string) (newPool string, err error)
+	SubmitBlock(fullBlock string) (err error)
+	SubmitBlockContext(ctx context.Context, fullBlock string) (err error)
+	Syncing() (syncing bool, syncStatus *SyncStatus, err error)
+	SyncingContext(ctx context.Context) (syncing bool, syncStatus *SyncStatus, err error)
+}
+
+var _ NimiqAPI = (*Client)(nil)
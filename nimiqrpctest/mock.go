@@ -0,0 +1,773 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by apigen from api.go. DO NOT EDIT.
+
+package nimiqrpctest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+var _ nimiqrpc.NimiqAPI = (*Mock)(nil)
+
+// Mock is a scriptable implementation of nimiqrpc.NimiqAPI. The behaviour of a method
+// is scripted by setting the corresponding function field. Calling a method of which the
+// function is not set returns an error that matches ErrNotScripted.
+//
+// The methods without a context call the function of their context-aware variant
+// with context.Background().
+type Mock struct {
+	recorder
+
+	AccountsFunc                            func(ctx context.Context) (accounts []nimiqrpc.Account, err error)
+	BlockNumberFunc                         func(ctx context.Context) (blockHeight int, err error)
+	ConsensusFunc                           func(ctx context.Context) (consensus string, err error)
+	ConstantFunc                            func(ctx context.Context, constant string) (value int, err error)
+	CreateAccountFunc                       func(ctx context.Context) (wallet *nimiqrpc.Wallet, err error)
+	CreateRawTransactionFunc                func(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHex string, err error)
+	DisconnectPoolFunc                      func(ctx context.Context) (err error)
+	GetAccountFunc                          func(ctx context.Context, address string) (account *nimiqrpc.Account, err error)
+	GetTypedAccountFunc                     func(ctx context.Context, address string) (account nimiqrpc.TypedAccount, err error)
+	GetBalanceFunc                          func(ctx context.Context, address string) (balance nimiqrpc.Luna, err error)
+	GetBlockByHashFunc                      func(ctx context.Context, blockHash string, fullTransactions bool) (block *nimiqrpc.Block, err error)
+	GetBlockByNumberFunc                    func(ctx context.Context, blockNumber int, fullTransactions bool) (block *nimiqrpc.Block, err error)
+	GetBlockTemplateFunc                    func(ctx context.Context, params ...interface{}) (template *nimiqrpc.BlockTemplate, err error)
+	GetBlockTransactionCountByHashFunc      func(ctx context.Context, blockHash string) (transactionCount int, err error)
+	GetBlockTransactionCountByNumberFunc    func(ctx context.Context, blockNumber int) (transactionCount int, err error)
+	GetRawTransactionInfoFunc               func(ctx context.Context, transactionHex string) (info *nimiqrpc.RawTransactionInfo, err error)
+	GetTransactionByBlockHashAndIndexFunc   func(ctx context.Context, blockHash string, index int) (transaction *nimiqrpc.Transaction, err error)
+	GetTransactionByBlockNumberAndIndexFunc func(ctx context.Context, blockNumber int, index int) (transaction *nimiqrpc.Transaction, err error)
+	GetTransactionByHashFunc                func(ctx context.Context, transactionHash string) (transaction *nimiqrpc.Transaction, err error)
+	GetTransactionReceiptFunc               func(ctx context.Context, transactionHash string) (transactionReceipt *nimiqrpc.TransactionReceipt, err error)
+	GetTransactionsByAddressFunc            func(ctx context.Context, address string, maxEntries int) (transactions []nimiqrpc.Transaction, err error)
+	GetWorkFunc                             func(ctx context.Context, params ...interface{}) (work *nimiqrpc.Work, err error)
+	HashrateFunc                            func(ctx context.Context) (hashrate float64, err error)
+	LogFunc                                 func(ctx context.Context, tag string, level nimiqrpc.LogLevel) (succes bool, err error)
+	MempoolFunc                             func(ctx context.Context) (mempool *nimiqrpc.Mempool, err error)
+	MempoolContentFunc                      func(ctx context.Context, includeTransactions bool) (content *nimiqrpc.MempoolContent, err error)
+	MinFeePerByteFunc                       func(ctx context.Context) (fee nimiqrpc.Luna, err error)
+	MinerAddressFunc                        func(ctx context.Context) (address string, err error)
+	MinerThreadsFunc                        func(ctx context.Context) (threads int, err error)
+	MiningFunc                              func(ctx context.Context) (status bool, err error)
+	PeerCountFunc                           func(ctx context.Context) (peers int, err error)
+	PeerListFunc                            func(ctx context.Context) (peers []nimiqrpc.Peer, err error)
+	PeerStateFunc                           func(ctx context.Context, peerAddress string, update ...string) (peer *nimiqrpc.Peer, err error)
+	PoolFunc                                func(ctx context.Context) (pool string, err error)
+	PoolConfirmedBalanceFunc                func(ctx context.Context) (balance nimiqrpc.Luna, err error)
+	PoolConnectionStateFunc                 func(ctx context.Context) (state nimiqrpc.PoolConnectionState, err error)
+	ResetConstantFunc                       func(ctx context.Context, constant string) (value int, err error)
+	SendRawTransactionFunc                  func(ctx context.Context, signedTransaction string) (transactionHash string, err error)
+	SendTransactionFunc                     func(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHash string, err error)
+	SetConstantFunc                         func(ctx context.Context, constant string, value int) (newValue int, err error)
+	SetMinFeePerByteFunc                    func(ctx context.Context, fee nimiqrpc.Luna) (newFee nimiqrpc.Luna, err error)
+	SetMinerThreadsFunc                     func(ctx context.Context, threads int) (newThreads int, err error)
+	SetMiningFunc                           func(ctx context.Context, enabled bool) (status bool, err error)
+	SetPoolFunc                             func(ctx context.Context, pool string) (newPool string, err error)
+	SubmitBlockFunc                         func(ctx context.Context, fullBlock string) (err error)
+	SyncingFunc                             func(ctx context.Context) (syncing bool, syncStatus *nimiqrpc.SyncStatus, err error)
+}
+
+// Accounts implements nimiqrpc.NimiqAPI
+func (m *Mock) Accounts() (accounts []nimiqrpc.Account, err error) {
+	return m.AccountsContext(context.Background())
+}
+
+// AccountsContext implements nimiqrpc.NimiqAPI
+func (m *Mock) AccountsContext(ctx context.Context) (accounts []nimiqrpc.Account, err error) {
+	m.record("Accounts", nil)
+	if m.AccountsFunc == nil {
+		err = fmt.Errorf("%w: Accounts", ErrNotScripted)
+		return
+	}
+	return m.AccountsFunc(ctx)
+}
+
+// BlockNumber implements nimiqrpc.NimiqAPI
+func (m *Mock) BlockNumber() (blockHeight int, err error) {
+	return m.BlockNumberContext(context.Background())
+}
+
+// BlockNumberContext implements nimiqrpc.NimiqAPI
+func (m *Mock) BlockNumberContext(ctx context.Context) (blockHeight int, err error) {
+	m.record("BlockNumber", nil)
+	if m.BlockNumberFunc == nil {
+		err = fmt.Errorf("%w: BlockNumber", ErrNotScripted)
+		return
+	}
+	return m.BlockNumberFunc(ctx)
+}
+
+// Consensus implements nimiqrpc.NimiqAPI
+func (m *Mock) Consensus() (consensus string, err error) {
+	return m.ConsensusContext(context.Background())
+}
+
+// ConsensusContext implements nimiqrpc.NimiqAPI
+func (m *Mock) ConsensusContext(ctx context.Context) (consensus string, err error) {
+	m.record("Consensus", nil)
+	if m.ConsensusFunc == nil {
+		err = fmt.Errorf("%w: Consensus", ErrNotScripted)
+		return
+	}
+	return m.ConsensusFunc(ctx)
+}
+
+// Constant implements nimiqrpc.NimiqAPI
+func (m *Mock) Constant(constant string) (value int, err error) {
+	return m.ConstantContext(context.Background(), constant)
+}
+
+// ConstantContext implements nimiqrpc.NimiqAPI
+func (m *Mock) ConstantContext(ctx context.Context, constant string) (value int, err error) {
+	m.record("Constant", []interface{}{constant})
+	if m.ConstantFunc == nil {
+		err = fmt.Errorf("%w: Constant", ErrNotScripted)
+		return
+	}
+	return m.ConstantFunc(ctx, constant)
+}
+
+// CreateAccount implements nimiqrpc.NimiqAPI
+func (m *Mock) CreateAccount() (wallet *nimiqrpc.Wallet, err error) {
+	return m.CreateAccountContext(context.Background())
+}
+
+// CreateAccountContext implements nimiqrpc.NimiqAPI
+func (m *Mock) CreateAccountContext(ctx context.Context) (wallet *nimiqrpc.Wallet, err error) {
+	m.record("CreateAccount", nil)
+	if m.CreateAccountFunc == nil {
+		err = fmt.Errorf("%w: CreateAccount", ErrNotScripted)
+		return
+	}
+	return m.CreateAccountFunc(ctx)
+}
+
+// CreateRawTransaction implements nimiqrpc.NimiqAPI
+func (m *Mock) CreateRawTransaction(trn nimiqrpc.OutgoingTransaction) (transactionHex string, err error) {
+	return m.CreateRawTransactionContext(context.Background(), trn)
+}
+
+// CreateRawTransactionContext implements nimiqrpc.NimiqAPI
+func (m *Mock) CreateRawTransactionContext(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHex string, err error) {
+	m.record("CreateRawTransaction", []interface{}{trn})
+	if m.CreateRawTransactionFunc == nil {
+		err = fmt.Errorf("%w: CreateRawTransaction", ErrNotScripted)
+		return
+	}
+	return m.CreateRawTransactionFunc(ctx, trn)
+}
+
+// DisconnectPool implements nimiqrpc.NimiqAPI
+func (m *Mock) DisconnectPool() (err error) {
+	return m.DisconnectPoolContext(context.Background())
+}
+
+// DisconnectPoolContext implements nimiqrpc.NimiqAPI
+func (m *Mock) DisconnectPoolContext(ctx context.Context) (err error) {
+	m.record("DisconnectPool", nil)
+	if m.DisconnectPoolFunc == nil {
+		err = fmt.Errorf("%w: DisconnectPool", ErrNotScripted)
+		return
+	}
+	return m.DisconnectPoolFunc(ctx)
+}
+
+// GetAccount implements nimiqrpc.NimiqAPI
+func (m *Mock) GetAccount(address string) (account *nimiqrpc.Account, err error) {
+	return m.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetAccountContext(ctx context.Context, address string) (account *nimiqrpc.Account, err error) {
+	m.record("GetAccount", []interface{}{address})
+	if m.GetAccountFunc == nil {
+		err = fmt.Errorf("%w: GetAccount", ErrNotScripted)
+		return
+	}
+	return m.GetAccountFunc(ctx, address)
+}
+
+// GetTypedAccount implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTypedAccount(address string) (account nimiqrpc.TypedAccount, err error) {
+	return m.GetTypedAccountContext(context.Background(), address)
+}
+
+// GetTypedAccountContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTypedAccountContext(ctx context.Context, address string) (account nimiqrpc.TypedAccount, err error) {
+	m.record("GetTypedAccount", []interface{}{address})
+	if m.GetTypedAccountFunc == nil {
+		err = fmt.Errorf("%w: GetTypedAccount", ErrNotScripted)
+		return
+	}
+	return m.GetTypedAccountFunc(ctx, address)
+}
+
+// GetBalance implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBalance(address string) (balance nimiqrpc.Luna, err error) {
+	return m.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBalanceContext(ctx context.Context, address string) (balance nimiqrpc.Luna, err error) {
+	m.record("GetBalance", []interface{}{address})
+	if m.GetBalanceFunc == nil {
+		err = fmt.Errorf("%w: GetBalance", ErrNotScripted)
+		return
+	}
+	return m.GetBalanceFunc(ctx, address)
+}
+
+// GetBlockByHash implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockByHash(blockHash string, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	return m.GetBlockByHashContext(context.Background(), blockHash, fullTransactions)
+}
+
+// GetBlockByHashContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockByHashContext(ctx context.Context, blockHash string, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	m.record("GetBlockByHash", []interface{}{blockHash, fullTransactions})
+	if m.GetBlockByHashFunc == nil {
+		err = fmt.Errorf("%w: GetBlockByHash", ErrNotScripted)
+		return
+	}
+	return m.GetBlockByHashFunc(ctx, blockHash, fullTransactions)
+}
+
+// GetBlockByNumber implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockByNumber(blockNumber int, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	return m.GetBlockByNumberContext(context.Background(), blockNumber, fullTransactions)
+}
+
+// GetBlockByNumberContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockByNumberContext(ctx context.Context, blockNumber int, fullTransactions bool) (block *nimiqrpc.Block, err error) {
+	m.record("GetBlockByNumber", []interface{}{blockNumber, fullTransactions})
+	if m.GetBlockByNumberFunc == nil {
+		err = fmt.Errorf("%w: GetBlockByNumber", ErrNotScripted)
+		return
+	}
+	return m.GetBlockByNumberFunc(ctx, blockNumber, fullTransactions)
+}
+
+// GetBlockTemplate implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTemplate(params ...interface{}) (template *nimiqrpc.BlockTemplate, err error) {
+	return m.GetBlockTemplateContext(context.Background(), params...)
+}
+
+// GetBlockTemplateContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTemplateContext(ctx context.Context, params ...interface{}) (template *nimiqrpc.BlockTemplate, err error) {
+	m.record("GetBlockTemplate", []interface{}{params})
+	if m.GetBlockTemplateFunc == nil {
+		err = fmt.Errorf("%w: GetBlockTemplate", ErrNotScripted)
+		return
+	}
+	return m.GetBlockTemplateFunc(ctx, params...)
+}
+
+// GetBlockTransactionCountByHash implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTransactionCountByHash(blockHash string) (transactionCount int, err error) {
+	return m.GetBlockTransactionCountByHashContext(context.Background(), blockHash)
+}
+
+// GetBlockTransactionCountByHashContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTransactionCountByHashContext(ctx context.Context, blockHash string) (transactionCount int, err error) {
+	m.record("GetBlockTransactionCountByHash", []interface{}{blockHash})
+	if m.GetBlockTransactionCountByHashFunc == nil {
+		err = fmt.Errorf("%w: GetBlockTransactionCountByHash", ErrNotScripted)
+		return
+	}
+	return m.GetBlockTransactionCountByHashFunc(ctx, blockHash)
+}
+
+// GetBlockTransactionCountByNumber implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTransactionCountByNumber(blockNumber int) (transactionCount int, err error) {
+	return m.GetBlockTransactionCountByNumberContext(context.Background(), blockNumber)
+}
+
+// GetBlockTransactionCountByNumberContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetBlockTransactionCountByNumberContext(ctx context.Context, blockNumber int) (transactionCount int, err error) {
+	m.record("GetBlockTransactionCountByNumber", []interface{}{blockNumber})
+	if m.GetBlockTransactionCountByNumberFunc == nil {
+		err = fmt.Errorf("%w: GetBlockTransactionCountByNumber", ErrNotScripted)
+		return
+	}
+	return m.GetBlockTransactionCountByNumberFunc(ctx, blockNumber)
+}
+
+// GetRawTransactionInfo implements nimiqrpc.NimiqAPI
+func (m *Mock) GetRawTransactionInfo(transactionHex string) (info *nimiqrpc.RawTransactionInfo, err error) {
+	return m.GetRawTransactionInfoContext(context.Background(), transactionHex)
+}
+
+// GetRawTransactionInfoContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetRawTransactionInfoContext(ctx context.Context, transactionHex string) (info *nimiqrpc.RawTransactionInfo, err error) {
+	m.record("GetRawTransactionInfo", []interface{}{transactionHex})
+	if m.GetRawTransactionInfoFunc == nil {
+		err = fmt.Errorf("%w: GetRawTransactionInfo", ErrNotScripted)
+		return
+	}
+	return m.GetRawTransactionInfoFunc(ctx, transactionHex)
+}
+
+// GetTransactionByBlockHashAndIndex implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByBlockHashAndIndex(blockHash string, index int) (transaction *nimiqrpc.Transaction, err error) {
+	return m.GetTransactionByBlockHashAndIndexContext(context.Background(), blockHash, index)
+}
+
+// GetTransactionByBlockHashAndIndexContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByBlockHashAndIndexContext(ctx context.Context, blockHash string, index int) (transaction *nimiqrpc.Transaction, err error) {
+	m.record("GetTransactionByBlockHashAndIndex", []interface{}{blockHash, index})
+	if m.GetTransactionByBlockHashAndIndexFunc == nil {
+		err = fmt.Errorf("%w: GetTransactionByBlockHashAndIndex", ErrNotScripted)
+		return
+	}
+	return m.GetTransactionByBlockHashAndIndexFunc(ctx, blockHash, index)
+}
+
+// GetTransactionByBlockNumberAndIndex implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByBlockNumberAndIndex(blockNumber int, index int) (transaction *nimiqrpc.Transaction, err error) {
+	return m.GetTransactionByBlockNumberAndIndexContext(context.Background(), blockNumber, index)
+}
+
+// GetTransactionByBlockNumberAndIndexContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByBlockNumberAndIndexContext(ctx context.Context, blockNumber int, index int) (transaction *nimiqrpc.Transaction, err error) {
+	m.record("GetTransactionByBlockNumberAndIndex", []interface{}{blockNumber, index})
+	if m.GetTransactionByBlockNumberAndIndexFunc == nil {
+		err = fmt.Errorf("%w: GetTransactionByBlockNumberAndIndex", ErrNotScripted)
+		return
+	}
+	return m.GetTransactionByBlockNumberAndIndexFunc(ctx, blockNumber, index)
+}
+
+// GetTransactionByHash implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByHash(transactionHash string) (transaction *nimiqrpc.Transaction, err error) {
+	return m.GetTransactionByHashContext(context.Background(), transactionHash)
+}
+
+// GetTransactionByHashContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionByHashContext(ctx context.Context, transactionHash string) (transaction *nimiqrpc.Transaction, err error) {
+	m.record("GetTransactionByHash", []interface{}{transactionHash})
+	if m.GetTransactionByHashFunc == nil {
+		err = fmt.Errorf("%w: GetTransactionByHash", ErrNotScripted)
+		return
+	}
+	return m.GetTransactionByHashFunc(ctx, transactionHash)
+}
+
+// GetTransactionReceipt implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionReceipt(transactionHash string) (transactionReceipt *nimiqrpc.TransactionReceipt, err error) {
+	return m.GetTransactionReceiptContext(context.Background(), transactionHash)
+}
+
+// GetTransactionReceiptContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionReceiptContext(ctx context.Context, transactionHash string) (transactionReceipt *nimiqrpc.TransactionReceipt, err error) {
+	m.record("GetTransactionReceipt", []interface{}{transactionHash})
+	if m.GetTransactionReceiptFunc == nil {
+		err = fmt.Errorf("%w: GetTransactionReceipt", ErrNotScripted)
+		return
+	}
+	return m.GetTransactionReceiptFunc(ctx, transactionHash)
+}
+
+// GetTransactionsByAddress implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionsByAddress(address string, maxEntries int) (transactions []nimiqrpc.Transaction, err error) {
+	return m.GetTransactionsByAddressContext(context.Background(), address, maxEntries)
+}
+
+// GetTransactionsByAddressContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetTransactionsByAddressContext(ctx context.Context, address string, maxEntries int) (transactions []nimiqrpc.Transaction, err error) {
+	m.record("GetTransactionsByAddress", []interface{}{address, maxEntries})
+	if m.GetTransactionsByAddressFunc == nil {
+		err = fmt.Errorf("%w: GetTransactionsByAddress", ErrNotScripted)
+		return
+	}
+	return m.GetTransactionsByAddressFunc(ctx, address, maxEntries)
+}
+
+// GetWork implements nimiqrpc.NimiqAPI
+func (m *Mock) GetWork(params ...interface{}) (work *nimiqrpc.Work, err error) {
+	return m.GetWorkContext(context.Background(), params...)
+}
+
+// GetWorkContext implements nimiqrpc.NimiqAPI
+func (m *Mock) GetWorkContext(ctx context.Context, params ...interface{}) (work *nimiqrpc.Work, err error) {
+	m.record("GetWork", []interface{}{params})
+	if m.GetWorkFunc == nil {
+		err = fmt.Errorf("%w: GetWork", ErrNotScripted)
+		return
+	}
+	return m.GetWorkFunc(ctx, params...)
+}
+
+// Hashrate implements nimiqrpc.NimiqAPI
+func (m *Mock) Hashrate() (hashrate float64, err error) {
+	return m.HashrateContext(context.Background())
+}
+
+// HashrateContext implements nimiqrpc.NimiqAPI
+func (m *Mock) HashrateContext(ctx context.Context) (hashrate float64, err error) {
+	m.record("Hashrate", nil)
+	if m.HashrateFunc == nil {
+		err = fmt.Errorf("%w: Hashrate", ErrNotScripted)
+		return
+	}
+	return m.HashrateFunc(ctx)
+}
+
+// Log implements nimiqrpc.NimiqAPI
+func (m *Mock) Log(tag string, level nimiqrpc.LogLevel) (succes bool, err error) {
+	return m.LogContext(context.Background(), tag, level)
+}
+
+// LogContext implements nimiqrpc.NimiqAPI
+func (m *Mock) LogContext(ctx context.Context, tag string, level nimiqrpc.LogLevel) (succes bool, err error) {
+	m.record("Log", []interface{}{tag, level})
+	if m.LogFunc == nil {
+		err = fmt.Errorf("%w: Log", ErrNotScripted)
+		return
+	}
+	return m.LogFunc(ctx, tag, level)
+}
+
+// Mempool implements nimiqrpc.NimiqAPI
+func (m *Mock) Mempool() (mempool *nimiqrpc.Mempool, err error) {
+	return m.MempoolContext(context.Background())
+}
+
+// MempoolContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MempoolContext(ctx context.Context) (mempool *nimiqrpc.Mempool, err error) {
+	m.record("Mempool", nil)
+	if m.MempoolFunc == nil {
+		err = fmt.Errorf("%w: Mempool", ErrNotScripted)
+		return
+	}
+	return m.MempoolFunc(ctx)
+}
+
+// MempoolContent implements nimiqrpc.NimiqAPI
+func (m *Mock) MempoolContent(includeTransactions bool) (content *nimiqrpc.MempoolContent, err error) {
+	return m.MempoolContentContext(context.Background(), includeTransactions)
+}
+
+// MempoolContentContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MempoolContentContext(ctx context.Context, includeTransactions bool) (content *nimiqrpc.MempoolContent, err error) {
+	m.record("MempoolContent", []interface{}{includeTransactions})
+	if m.MempoolContentFunc == nil {
+		err = fmt.Errorf("%w: MempoolContent", ErrNotScripted)
+		return
+	}
+	return m.MempoolContentFunc(ctx, includeTransactions)
+}
+
+// MinFeePerByte implements nimiqrpc.NimiqAPI
+func (m *Mock) MinFeePerByte() (fee nimiqrpc.Luna, err error) {
+	return m.MinFeePerByteContext(context.Background())
+}
+
+// MinFeePerByteContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MinFeePerByteContext(ctx context.Context) (fee nimiqrpc.Luna, err error) {
+	m.record("MinFeePerByte", nil)
+	if m.MinFeePerByteFunc == nil {
+		err = fmt.Errorf("%w: MinFeePerByte", ErrNotScripted)
+		return
+	}
+	return m.MinFeePerByteFunc(ctx)
+}
+
+// MinerAddress implements nimiqrpc.NimiqAPI
+func (m *Mock) MinerAddress() (address string, err error) {
+	return m.MinerAddressContext(context.Background())
+}
+
+// MinerAddressContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MinerAddressContext(ctx context.Context) (address string, err error) {
+	m.record("MinerAddress", nil)
+	if m.MinerAddressFunc == nil {
+		err = fmt.Errorf("%w: MinerAddress", ErrNotScripted)
+		return
+	}
+	return m.MinerAddressFunc(ctx)
+}
+
+// MinerThreads implements nimiqrpc.NimiqAPI
+func (m *Mock) MinerThreads() (threads int, err error) {
+	return m.MinerThreadsContext(context.Background())
+}
+
+// MinerThreadsContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MinerThreadsContext(ctx context.Context) (threads int, err error) {
+	m.record("MinerThreads", nil)
+	if m.MinerThreadsFunc == nil {
+		err = fmt.Errorf("%w: MinerThreads", ErrNotScripted)
+		return
+	}
+	return m.MinerThreadsFunc(ctx)
+}
+
+// Mining implements nimiqrpc.NimiqAPI
+func (m *Mock) Mining() (status bool, err error) {
+	return m.MiningContext(context.Background())
+}
+
+// MiningContext implements nimiqrpc.NimiqAPI
+func (m *Mock) MiningContext(ctx context.Context) (status bool, err error) {
+	m.record("Mining", nil)
+	if m.MiningFunc == nil {
+		err = fmt.Errorf("%w: Mining", ErrNotScripted)
+		return
+	}
+	return m.MiningFunc(ctx)
+}
+
+// PeerCount implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerCount() (peers int, err error) {
+	return m.PeerCountContext(context.Background())
+}
+
+// PeerCountContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerCountContext(ctx context.Context) (peers int, err error) {
+	m.record("PeerCount", nil)
+	if m.PeerCountFunc == nil {
+		err = fmt.Errorf("%w: PeerCount", ErrNotScripted)
+		return
+	}
+	return m.PeerCountFunc(ctx)
+}
+
+// PeerList implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerList() (peers []nimiqrpc.Peer, err error) {
+	return m.PeerListContext(context.Background())
+}
+
+// PeerListContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerListContext(ctx context.Context) (peers []nimiqrpc.Peer, err error) {
+	m.record("PeerList", nil)
+	if m.PeerListFunc == nil {
+		err = fmt.Errorf("%w: PeerList", ErrNotScripted)
+		return
+	}
+	return m.PeerListFunc(ctx)
+}
+
+// PeerState implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerState(peerAddress string, update ...string) (peer *nimiqrpc.Peer, err error) {
+	return m.PeerStateContext(context.Background(), peerAddress, update...)
+}
+
+// PeerStateContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PeerStateContext(ctx context.Context, peerAddress string, update ...string) (peer *nimiqrpc.Peer, err error) {
+	m.record("PeerState", []interface{}{peerAddress, update})
+	if m.PeerStateFunc == nil {
+		err = fmt.Errorf("%w: PeerState", ErrNotScripted)
+		return
+	}
+	return m.PeerStateFunc(ctx, peerAddress, update...)
+}
+
+// Pool implements nimiqrpc.NimiqAPI
+func (m *Mock) Pool() (pool string, err error) {
+	return m.PoolContext(context.Background())
+}
+
+// PoolContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PoolContext(ctx context.Context) (pool string, err error) {
+	m.record("Pool", nil)
+	if m.PoolFunc == nil {
+		err = fmt.Errorf("%w: Pool", ErrNotScripted)
+		return
+	}
+	return m.PoolFunc(ctx)
+}
+
+// PoolConfirmedBalance implements nimiqrpc.NimiqAPI
+func (m *Mock) PoolConfirmedBalance() (balance nimiqrpc.Luna, err error) {
+	return m.PoolConfirmedBalanceContext(context.Background())
+}
+
+// PoolConfirmedBalanceContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PoolConfirmedBalanceContext(ctx context.Context) (balance nimiqrpc.Luna, err error) {
+	m.record("PoolConfirmedBalance", nil)
+	if m.PoolConfirmedBalanceFunc == nil {
+		err = fmt.Errorf("%w: PoolConfirmedBalance", ErrNotScripted)
+		return
+	}
+	return m.PoolConfirmedBalanceFunc(ctx)
+}
+
+// PoolConnectionState implements nimiqrpc.NimiqAPI
+func (m *Mock) PoolConnectionState() (state nimiqrpc.PoolConnectionState, err error) {
+	return m.PoolConnectionStateContext(context.Background())
+}
+
+// PoolConnectionStateContext implements nimiqrpc.NimiqAPI
+func (m *Mock) PoolConnectionStateContext(ctx context.Context) (state nimiqrpc.PoolConnectionState, err error) {
+	m.record("PoolConnectionState", nil)
+	if m.PoolConnectionStateFunc == nil {
+		err = fmt.Errorf("%w: PoolConnectionState", ErrNotScripted)
+		return
+	}
+	return m.PoolConnectionStateFunc(ctx)
+}
+
+// ResetConstant implements nimiqrpc.NimiqAPI
+func (m *Mock) ResetConstant(constant string) (value int, err error) {
+	return m.ResetConstantContext(context.Background(), constant)
+}
+
+// ResetConstantContext implements nimiqrpc.NimiqAPI
+func (m *Mock) ResetConstantContext(ctx context.Context, constant string) (value int, err error) {
+	m.record("ResetConstant", []interface{}{constant})
+	if m.ResetConstantFunc == nil {
+		err = fmt.Errorf("%w: ResetConstant", ErrNotScripted)
+		return
+	}
+	return m.ResetConstantFunc(ctx, constant)
+}
+
+// SendRawTransaction implements nimiqrpc.NimiqAPI
+func (m *Mock) SendRawTransaction(signedTransaction string) (transactionHash string, err error) {
+	return m.SendRawTransactionContext(context.Background(), signedTransaction)
+}
+
+// SendRawTransactionContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SendRawTransactionContext(ctx context.Context, signedTransaction string) (transactionHash string, err error) {
+	m.record("SendRawTransaction", []interface{}{signedTransaction})
+	if m.SendRawTransactionFunc == nil {
+		err = fmt.Errorf("%w: SendRawTransaction", ErrNotScripted)
+		return
+	}
+	return m.SendRawTransactionFunc(ctx, signedTransaction)
+}
+
+// SendTransaction implements nimiqrpc.NimiqAPI
+func (m *Mock) SendTransaction(trn nimiqrpc.OutgoingTransaction) (transactionHash string, err error) {
+	return m.SendTransactionContext(context.Background(), trn)
+}
+
+// SendTransactionContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SendTransactionContext(ctx context.Context, trn nimiqrpc.OutgoingTransaction) (transactionHash string, err error) {
+	m.record("SendTransaction", []interface{}{trn})
+	if m.SendTransactionFunc == nil {
+		err = fmt.Errorf("%w: SendTransaction", ErrNotScripted)
+		return
+	}
+	return m.SendTransactionFunc(ctx, trn)
+}
+
+// SetConstant implements nimiqrpc.NimiqAPI
+func (m *Mock) SetConstant(constant string, value int) (newValue int, err error) {
+	return m.SetConstantContext(context.Background(), constant, value)
+}
+
+// SetConstantContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SetConstantContext(ctx context.Context, constant string, value int) (newValue int, err error) {
+	m.record("SetConstant", []interface{}{constant, value})
+	if m.SetConstantFunc == nil {
+		err = fmt.Errorf("%w: SetConstant", ErrNotScripted)
+		return
+	}
+	return m.SetConstantFunc(ctx, constant, value)
+}
+
+// SetMinFeePerByte implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMinFeePerByte(fee nimiqrpc.Luna) (newFee nimiqrpc.Luna, err error) {
+	return m.SetMinFeePerByteContext(context.Background(), fee)
+}
+
+// SetMinFeePerByteContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMinFeePerByteContext(ctx context.Context, fee nimiqrpc.Luna) (newFee nimiqrpc.Luna, err error) {
+	m.record("SetMinFeePerByte", []interface{}{fee})
+	if m.SetMinFeePerByteFunc == nil {
+		err = fmt.Errorf("%w: SetMinFeePerByte", ErrNotScripted)
+		return
+	}
+	return m.SetMinFeePerByteFunc(ctx, fee)
+}
+
+// SetMinerThreads implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMinerThreads(threads int) (newThreads int, err error) {
+	return m.SetMinerThreadsContext(context.Background(), threads)
+}
+
+// SetMinerThreadsContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMinerThreadsContext(ctx context.Context, threads int) (newThreads int, err error) {
+	m.record("SetMinerThreads", []interface{}{threads})
+	if m.SetMinerThreadsFunc == nil {
+		err = fmt.Errorf("%w: SetMinerThreads", ErrNotScripted)
+		return
+	}
+	return m.SetMinerThreadsFunc(ctx, threads)
+}
+
+// SetMining implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMining(enabled bool) (status bool, err error) {
+	return m.SetMiningContext(context.Background(), enabled)
+}
+
+// SetMiningContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SetMiningContext(ctx context.Context, enabled bool) (status bool, err error) {
+	m.record("SetMining", []interface{}{enabled})
+	if m.SetMiningFunc == nil {
+		err = fmt.Errorf("%w: SetMining", ErrNotScripted)
+		return
+	}
+	return m.SetMiningFunc(ctx, enabled)
+}
+
+// SetPool implements nimiqrpc.NimiqAPI
+func (m *Mock) SetPool(pool string) (newPool string, err error) {
+	return m.SetPoolContext(context.Background(), pool)
+}
+
+// SetPoolContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SetPoolContext(ctx context.Context, pool string) (newPool string, err error) {
+	m.record("SetPool", []interface{}{pool})
+	if m.SetPoolFunc == nil {
+		err = fmt.Errorf("%w: SetPool", ErrNotScripted)
+		return
+	}
+	return m.SetPoolFunc(ctx, pool)
+}
+
+// SubmitBlock implements nimiqrpc.NimiqAPI
+func (m *Mock) SubmitBlock(fullBlock string) (err error) {
+	return m.SubmitBlockContext(context.Background(), fullBlock)
+}
+
+// SubmitBlockContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SubmitBlockContext(ctx context.Context, fullBlock string) (err error) {
+	m.record("SubmitBlock", []interface{}{fullBlock})
+	if m.SubmitBlockFunc == nil {
+		err = fmt.Errorf("%w: SubmitBlock", ErrNotScripted)
+		return
+	}
+	return m.SubmitBlockFunc(ctx, fullBlock)
+}
+
+// Syncing implements nimiqrpc.NimiqAPI
+func (m *Mock) Syncing() (syncing bool, syncStatus *nimiqrpc.SyncStatus, err error) {
+	return m.SyncingContext(context.Background())
+}
+
+// SyncingContext implements nimiqrpc.NimiqAPI
+func (m *Mock) SyncingContext(ctx context.Context) (syncing bool, syncStatus *nimiqrpc.SyncStatus, err error) {
+	m.record("Syncing", nil)
+	if m.SyncingFunc == nil {
+		err = fmt.Errorf("%w: Syncing", ErrNotScripted)
+		return
+	}
+	return m.SyncingFunc(ctx)
+}
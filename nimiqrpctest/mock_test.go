@@ -0,0 +1,83 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpctest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+func TestMock(t *testing.T) {
+	mock := &Mock{
+		GetBalanceFunc: func(ctx context.Context, address string) (nimiqrpc.Luna, error) {
+			if address != "NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2" {
+				t.Errorf("unexpected address %q", address)
+			}
+			return 100000, nil
+		},
+	}
+
+	var api nimiqrpc.NimiqAPI = mock
+	balance, err := api.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 100000 {
+		t.Errorf("unexpected balance %d", balance)
+	}
+
+	if _, err := api.BlockNumberContext(context.Background()); !errors.Is(err, ErrNotScripted) {
+		t.Errorf("expected %v, got %v", ErrNotScripted, err)
+	}
+
+	calls := mock.Calls()
+	if len(calls) != 2 || calls[0].Method != "GetBalance" || calls[1].Method != "BlockNumber" {
+		t.Fatalf("unexpected calls %+v", calls)
+	}
+	if calls[0].Args[0] != "NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2" {
+		t.Errorf("unexpected arguments %+v", calls[0].Args)
+	}
+	if mock.CallCount("GetBalance") != 1 {
+		t.Errorf("expected 1 call to GetBalance, got %d", mock.CallCount("GetBalance"))
+	}
+
+	mock.Reset()
+	if len(mock.Calls()) != 0 {
+		t.Error("expected calls to be reset")
+	}
+}
+
+func TestMockTypedAccount(t *testing.T) {
+	mock := &Mock{
+		GetTypedAccountFunc: func(ctx context.Context, address string) (nimiqrpc.TypedAccount, error) {
+			return &nimiqrpc.VestingAccount{VestingStepBlocks: 100}, nil
+		},
+	}
+
+	var api nimiqrpc.NimiqAPI = mock
+	account, err := api.GetTypedAccount("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vesting, ok := account.(*nimiqrpc.VestingAccount); !ok || vesting.VestingStepBlocks != 100 {
+		t.Errorf("unexpected account %+v", account)
+	}
+	if mock.CallCount("GetTypedAccount") != 1 {
+		t.Errorf("expected 1 call to GetTypedAccount, got %d", mock.CallCount("GetTypedAccount"))
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package nimiqrpctest provides a scriptable implementation of nimiqrpc.NimiqAPI for testing
+code that depends on a Nimiq node, without running a node.
+
+How to use this package:
+
+  mock := &nimiqrpctest.Mock{
+      GetBalanceFunc: func(ctx context.Context, address string) (nimiqrpc.Luna, error) {
+          return 100000, nil
+      },
+  }
+
+  // Pass the mock to the code under test, which accepts a nimiqrpc.NimiqAPI
+  balance, err := mock.GetBalance("NQ52 V4BF 52J3 0PM6 BG4M 9QY1 RUYS UAL6 CJD2")
+
+  // Inspect the calls that were made
+  calls := mock.Calls()
+
+*/
+package nimiqrpctest
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNotScripted is returned by a method of Mock of which the behaviour is not scripted
+var ErrNotScripted = errors.New("method not scripted")
+
+// Call is a call that was made to a Mock
+type Call struct {
+	Method string        // name of the method, without the Context suffix
+	Args   []interface{} // arguments of the call, excluding the context
+}
+
+// recorder records the calls made to a Mock
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+// record records a call
+func (r *recorder) record(method string, args []interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all calls that were made, in order
+func (r *recorder) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	calls := make([]Call, len(r.calls))
+	copy(calls, r.calls)
+	return calls
+}
+
+// CallCount returns the number of calls that were made to the given method
+func (r *recorder) CallCount(method string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, call := range r.calls {
+		if call.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+// Reset removes all recorded calls
+func (r *recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = nil
+}
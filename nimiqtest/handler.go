@@ -0,0 +1,747 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// request is a JSON-RPC request
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      interface{}     `json:"id"`
+}
+
+// response is a JSON-RPC response
+type response struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Result  interface{} `json:"result"`
+	Error   *rpcError   `json:"error,omitempty"`
+	ID      interface{} `json:"id"`
+}
+
+// rpcError is a JSON-RPC error object
+type rpcError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// serveHTTP handles single and batch JSON-RPC requests
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	s.requests++
+	intercept := s.intercept
+	s.mu.Unlock()
+	if intercept != nil {
+		if failure := intercept(r, requestMethods(body)); failure != nil {
+			s.fail(w, r, failure)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	encoder := json.NewEncoder(w)
+
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var reqs []request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			encoder.Encode(errorResponse(nil, CodeParseError, err.Error()))
+			return
+		}
+
+		resps := make([]response, 0, len(reqs))
+		for _, req := range reqs {
+			resps = append(resps, s.handle(req))
+		}
+		encoder.Encode(resps)
+		return
+	}
+
+	var req request
+	if err := json.Unmarshal(body, &req); err != nil {
+		encoder.Encode(errorResponse(nil, CodeParseError, err.Error()))
+		return
+	}
+	encoder.Encode(s.handle(req))
+}
+
+// fail responds to the request with the failure
+func (s *Server) fail(w http.ResponseWriter, r *http.Request, failure *Failure) {
+	if failure.Block {
+		select {
+		case <-r.Context().Done():
+		case <-s.closed:
+		}
+		return
+	}
+
+	status := failure.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	w.WriteHeader(status)
+	w.Write([]byte(failure.Body))
+}
+
+// requestMethods returns the methods of the single or batch JSON-RPC request in body
+func requestMethods(body []byte) []string {
+	var reqs []request
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		json.Unmarshal(body, &reqs)
+	} else {
+		var req request
+		if json.Unmarshal(body, &req) == nil {
+			reqs = append(reqs, req)
+		}
+	}
+
+	methods := make([]string, 0, len(reqs))
+	for _, req := range reqs {
+		methods = append(methods, req.Method)
+	}
+	return methods
+}
+
+// handle dispatches a single JSON-RPC request to its handler
+func (s *Server) handle(req request) response {
+	s.mu.Lock()
+	handler, ok := s.handlers[req.Method]
+	s.mu.Unlock()
+	if !ok {
+		return errorResponse(req.ID, CodeMethodNotFound, "Method not found")
+	}
+
+	params, err := splitParams(req.Params)
+	if err != nil {
+		return errorResponse(req.ID, CodeInvalidParams, err.Error())
+	}
+
+	result, err := handler(params)
+	if err != nil {
+		if rpcErr, ok := err.(*nimiqrpc.RPCError); ok {
+			resp := errorResponse(req.ID, rpcErr.Code, rpcErr.Message)
+			resp.Error.Data = rpcErr.Data
+			return resp
+		}
+		return errorResponse(req.ID, CodeInternalError, err.Error())
+	}
+
+	return response{
+		JSONRPC: "2.0",
+		Result:  result,
+		ID:      req.ID,
+	}
+}
+
+// errorResponse returns a JSON-RPC error response
+func errorResponse(id interface{}, code int, message string) response {
+	return response{
+		JSONRPC: "2.0",
+		Error: &rpcError{
+			Code:    code,
+			Message: message,
+		},
+		ID: id,
+	}
+}
+
+// splitParams splits the parameters of a request. Parameters are either sent by position
+// in an array, or as a single object.
+func splitParams(raw json.RawMessage) ([]json.RawMessage, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] != '[' {
+		return []json.RawMessage{raw}, nil
+	}
+
+	var params []json.RawMessage
+	err := json.Unmarshal(raw, &params)
+	return params, err
+}
+
+// decodeParams decodes the parameters into the given values. Missing optional parameters
+// leave their values untouched.
+func decodeParams(params []json.RawMessage, required int, values ...interface{}) error {
+	if len(params) < required {
+		return &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Missing parameters"}
+	}
+
+	for i, value := range values {
+		if i >= len(params) {
+			break
+		}
+		if err := json.Unmarshal(params[i], value); err != nil {
+			return &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// registerHandlers registers the built-in handlers of the core-js methods
+func (s *Server) registerHandlers() {
+	locked := func(handler HandlerFunc) HandlerFunc {
+		return func(params []json.RawMessage) (interface{}, error) {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			return handler(params)
+		}
+	}
+
+	for method, handler := range map[string]HandlerFunc{
+		"accounts":                            s.handleAccounts,
+		"blockNumber":                         s.handleBlockNumber,
+		"consensus":                           s.handleConsensus,
+		"constant":                            s.handleConstant,
+		"createAccount":                       s.handleCreateAccount,
+		"createRawTransaction":                s.handleCreateRawTransaction,
+		"getAccount":                          s.handleGetAccount,
+		"getBalance":                          s.handleGetBalance,
+		"getBlockByHash":                      s.handleGetBlockByHash,
+		"getBlockByNumber":                    s.handleGetBlockByNumber,
+		"getBlockTemplate":                    s.handleGetBlockTemplate,
+		"getBlockTransactionCountByHash":      s.handleGetBlockTransactionCountByHash,
+		"getBlockTransactionCountByNumber":    s.handleGetBlockTransactionCountByNumber,
+		"getRawTransactionInfo":               s.handleGetRawTransactionInfo,
+		"getTransactionByBlockHashAndIndex":   s.handleGetTransactionByBlockHashAndIndex,
+		"getTransactionByBlockNumberAndIndex": s.handleGetTransactionByBlockNumberAndIndex,
+		"getTransactionByHash":                s.handleGetTransactionByHash,
+		"getTransactionReceipt":               s.handleGetTransactionReceipt,
+		"getTransactionsByAddress":            s.handleGetTransactionsByAddress,
+		"getWork":                             s.handleGetWork,
+		"hashrate":                            s.handleHashrate,
+		"log":                                 s.handleLog,
+		"mempool":                             s.handleMempool,
+		"mempoolContent":                      s.handleMempoolContent,
+		"minFeePerByte":                       s.handleMinFeePerByte,
+		"minerAddress":                        s.handleMinerAddress,
+		"minerThreads":                        s.handleMinerThreads,
+		"mining":                              s.handleMining,
+		"peerCount":                           s.handlePeerCount,
+		"peerList":                            s.handlePeerList,
+		"peerState":                           s.handlePeerState,
+		"pool":                                s.handlePool,
+		"poolConfirmedBalance":                s.handlePoolConfirmedBalance,
+		"poolConnectionState":                 s.handlePoolConnectionState,
+		"sendRawTransaction":                  s.handleSendRawTransaction,
+		"sendTransaction":                     s.handleSendTransaction,
+		"submitBlock":                         s.handleSubmitBlock,
+		"syncing":                             s.handleSyncing,
+	} {
+		s.handlers[method] = locked(handler)
+	}
+}
+
+func (s *Server) handleAccounts(params []json.RawMessage) (interface{}, error) {
+	return s.ownedAccounts(), nil
+}
+
+func (s *Server) handleBlockNumber(params []json.RawMessage) (interface{}, error) {
+	return len(s.blocks), nil
+}
+
+func (s *Server) handleConsensus(params []json.RawMessage) (interface{}, error) {
+	return s.consensus, nil
+}
+
+// handleConstant returns the value of a constant. If a value is given the constant is
+// overridden, unless the value is "reset" which restores the default value.
+func (s *Server) handleConstant(params []json.RawMessage) (interface{}, error) {
+	var constant string
+	var value json.RawMessage
+	if err := decodeParams(params, 1, &constant, &value); err != nil {
+		return nil, err
+	}
+
+	defaultValue, ok := defaultConstants[constant]
+	if !ok {
+		return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Unknown constant " + constant}
+	}
+
+	if len(value) > 0 {
+		var reset string
+		var override int
+		switch {
+		case json.Unmarshal(value, &reset) == nil && reset == "reset":
+			delete(s.constants, constant)
+		case json.Unmarshal(value, &override) == nil:
+			s.constants[constant] = override
+		default:
+			return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Invalid constant value"}
+		}
+	}
+
+	if override, ok := s.constants[constant]; ok {
+		return override, nil
+	}
+	return defaultValue, nil
+}
+
+func (s *Server) handleCreateAccount(params []json.RawMessage) (interface{}, error) {
+	account := s.newAccount(0)
+	s.owned = append(s.owned, account.Address)
+
+	return nimiqrpc.Wallet{
+		ID:        account.ID,
+		Address:   account.Address,
+		PublicKey: s.nextHash("publicKey"),
+	}, nil
+}
+
+// handleCreateRawTransaction returns the transaction as hex-encoded JSON. This encoding is
+// specific to the Server, which accepts it in sendRawTransaction.
+func (s *Server) handleCreateRawTransaction(params []json.RawMessage) (interface{}, error) {
+	var trn nimiqrpc.OutgoingTransaction
+	if err := decodeParams(params, 1, &trn); err != nil {
+		return nil, err
+	}
+
+	raw, err := json.Marshal(trn)
+	return hex.EncodeToString(raw), err
+}
+
+func (s *Server) handleGetAccount(params []json.RawMessage) (interface{}, error) {
+	var address string
+	if err := decodeParams(params, 1, &address); err != nil {
+		return nil, err
+	}
+	return s.account(address), nil
+}
+
+func (s *Server) handleGetBalance(params []json.RawMessage) (interface{}, error) {
+	var address string
+	if err := decodeParams(params, 1, &address); err != nil {
+		return nil, err
+	}
+	return s.account(address).Balance, nil
+}
+
+func (s *Server) handleGetBlockByHash(params []json.RawMessage) (interface{}, error) {
+	var hash string
+	var fullTransactions bool
+	if err := decodeParams(params, 1, &hash, &fullTransactions); err != nil {
+		return nil, err
+	}
+	return s.blockResult(s.blockByHash(hash), fullTransactions)
+}
+
+func (s *Server) handleGetBlockByNumber(params []json.RawMessage) (interface{}, error) {
+	var number int
+	var fullTransactions bool
+	if err := decodeParams(params, 1, &number, &fullTransactions); err != nil {
+		return nil, err
+	}
+	return s.blockResult(s.block(number), fullTransactions)
+}
+
+func (s *Server) handleGetBlockTemplate(params []json.RawMessage) (interface{}, error) {
+	minerAddress := s.miner.ID
+	extraData := ""
+	if err := decodeParams(params, 0, &minerAddress, &extraData); err != nil {
+		return nil, err
+	}
+
+	head := s.blocks[len(s.blocks)-1]
+	return nimiqrpc.BlockTemplate{
+		Header: nimiqrpc.BlockTemplateHeader{
+			Version:     1,
+			PrevHash:    head.Hash,
+			AccountHash: head.AccountHash,
+			NBits:       0x1f010000,
+			Height:      head.Number + 1,
+		},
+		Body: nimiqrpc.BlockTemplateBody{
+			MinerAddr:      minerAddress,
+			ExtraData:      extraData,
+			Transactions:   []string{},
+			PrunedAccounts: []string{},
+			MerkleHashes:   []string{},
+		},
+		Target: 0x1f010000,
+	}, nil
+}
+
+func (s *Server) handleGetBlockTransactionCountByHash(params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := decodeParams(params, 1, &hash); err != nil {
+		return nil, err
+	}
+
+	block := s.blockByHash(hash)
+	if block == nil {
+		return nil, nil
+	}
+	return len(block.TransactionHashes), nil
+}
+
+func (s *Server) handleGetBlockTransactionCountByNumber(params []json.RawMessage) (interface{}, error) {
+	var number int
+	if err := decodeParams(params, 1, &number); err != nil {
+		return nil, err
+	}
+
+	block := s.block(number)
+	if block == nil {
+		return nil, nil
+	}
+	return len(block.TransactionHashes), nil
+}
+
+// handleGetRawTransactionInfo returns a transaction encoded by createRawTransaction. A transaction
+// is valid if it has a positive value, since the Server does not sign transactions.
+func (s *Server) handleGetRawTransactionInfo(params []json.RawMessage) (interface{}, error) {
+	var transactionHex string
+	if err := decodeParams(params, 1, &transactionHex); err != nil {
+		return nil, err
+	}
+
+	trn, hash, err := decodeRawTransaction(transactionHex)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := s.transaction(hash)
+	if tx == nil {
+		tx = s.newTransaction(trn, hash)
+	}
+	return nimiqrpc.RawTransactionInfo{
+		Transaction: *tx,
+		Valid:       trn.Value > 0,
+		InMempool:   s.inMempool(hash),
+	}, nil
+}
+
+func (s *Server) handleGetTransactionByBlockHashAndIndex(params []json.RawMessage) (interface{}, error) {
+	var hash string
+	var index int
+	if err := decodeParams(params, 2, &hash, &index); err != nil {
+		return nil, err
+	}
+
+	block := s.blockByHash(hash)
+	if block == nil || index < 0 || index >= len(block.TransactionHashes) {
+		return nil, nil
+	}
+	return s.transaction(block.TransactionHashes[index]), nil
+}
+
+func (s *Server) handleGetTransactionByBlockNumberAndIndex(params []json.RawMessage) (interface{}, error) {
+	var number, index int
+	if err := decodeParams(params, 2, &number, &index); err != nil {
+		return nil, err
+	}
+
+	block := s.block(number)
+	if block == nil || index < 0 || index >= len(block.TransactionHashes) {
+		return nil, nil
+	}
+	return s.transaction(block.TransactionHashes[index]), nil
+}
+
+func (s *Server) handleGetTransactionByHash(params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := decodeParams(params, 1, &hash); err != nil {
+		return nil, err
+	}
+
+	tx := s.transaction(hash)
+	if tx == nil {
+		return nil, nil
+	}
+	return tx, nil
+}
+
+func (s *Server) handleGetTransactionReceipt(params []json.RawMessage) (interface{}, error) {
+	var hash string
+	if err := decodeParams(params, 1, &hash); err != nil {
+		return nil, err
+	}
+
+	tx := s.transaction(hash)
+	if tx == nil || tx.BlockNumber == 0 {
+		return nil, nil
+	}
+	return nimiqrpc.TransactionReceipt{
+		TransactionHash:  tx.Hash,
+		TransactionIndex: tx.TransactionIndex,
+		BlockHash:        tx.BlockHash,
+		BlockNumber:      tx.BlockNumber,
+		Confirmations:    tx.Confirmations,
+		Timestamp:        tx.Timestamp,
+	}, nil
+}
+
+func (s *Server) handleGetTransactionsByAddress(params []json.RawMessage) (interface{}, error) {
+	var address string
+	maxEntries := 1000
+	if err := decodeParams(params, 1, &address, &maxEntries); err != nil {
+		return nil, err
+	}
+	return s.transactionsByAddress(address, maxEntries), nil
+}
+
+func (s *Server) handleGetWork(params []json.RawMessage) (interface{}, error) {
+	head := s.blocks[len(s.blocks)-1]
+	return nimiqrpc.Work{
+		Data:      head.Hash,
+		Suffix:    head.BodyHash,
+		Target:    0x1f010000,
+		Algorithm: "nimiq-argon2",
+	}, nil
+}
+
+func (s *Server) handleHashrate(params []json.RawMessage) (interface{}, error) {
+	return s.hashrate, nil
+}
+
+func (s *Server) handleLog(params []json.RawMessage) (interface{}, error) {
+	var tag, level string
+	if err := decodeParams(params, 2, &tag, &level); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func (s *Server) handleMempool(params []json.RawMessage) (interface{}, error) {
+	return s.mempoolResult(), nil
+}
+
+func (s *Server) handleMempoolContent(params []json.RawMessage) (interface{}, error) {
+	var includeTransactions bool
+	if err := decodeParams(params, 0, &includeTransactions); err != nil {
+		return nil, err
+	}
+
+	if includeTransactions {
+		transactions := make([]nimiqrpc.Transaction, 0, len(s.mempool))
+		for _, tx := range s.mempool {
+			transactions = append(transactions, *tx)
+		}
+		return transactions, nil
+	}
+
+	hashes := make([]string, 0, len(s.mempool))
+	for _, tx := range s.mempool {
+		hashes = append(hashes, tx.Hash)
+	}
+	return hashes, nil
+}
+
+// handleMinFeePerByte returns the minimum fee per byte, after setting it if a fee is given
+func (s *Server) handleMinFeePerByte(params []json.RawMessage) (interface{}, error) {
+	fee := s.minFeePerByte
+	if err := decodeParams(params, 0, &fee); err != nil {
+		return nil, err
+	}
+	if fee < 0 {
+		return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Fee must not be negative"}
+	}
+
+	s.minFeePerByte = fee
+	return s.minFeePerByte, nil
+}
+
+// handleMining returns whether the server is mining, after starting or stopping it if enabled is given
+func (s *Server) handleMining(params []json.RawMessage) (interface{}, error) {
+	enabled := s.mining
+	if err := decodeParams(params, 0, &enabled); err != nil {
+		return nil, err
+	}
+
+	s.mining = enabled
+	s.hashrate = 0
+	if s.mining {
+		s.hashrate = float64(s.minerThreads * hashesPerThread)
+	}
+	return s.mining, nil
+}
+
+func (s *Server) handleMinerAddress(params []json.RawMessage) (interface{}, error) {
+	return s.miner.Address, nil
+}
+
+// handleMinerThreads returns the number of miner threads, after setting it if threads is given
+func (s *Server) handleMinerThreads(params []json.RawMessage) (interface{}, error) {
+	threads := s.minerThreads
+	if err := decodeParams(params, 0, &threads); err != nil {
+		return nil, err
+	}
+	if threads < 1 {
+		return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Threads must be positive"}
+	}
+
+	s.minerThreads = threads
+	if s.mining {
+		s.hashrate = float64(s.minerThreads * hashesPerThread)
+	}
+	return s.minerThreads, nil
+}
+
+func (s *Server) handlePeerCount(params []json.RawMessage) (interface{}, error) {
+	return len(s.peers), nil
+}
+
+func (s *Server) handlePeerList(params []json.RawMessage) (interface{}, error) {
+	peers := make([]nimiqrpc.Peer, len(s.peers))
+	copy(peers, s.peers)
+	return peers, nil
+}
+
+func (s *Server) handlePeerState(params []json.RawMessage) (interface{}, error) {
+	var address, update string
+	if err := decodeParams(params, 1, &address, &update); err != nil {
+		return nil, err
+	}
+
+	for i := range s.peers {
+		if s.peers[i].Address != address {
+			continue
+		}
+		switch update {
+		case "connect":
+			s.peers[i].ConnectionState = 5
+		case "disconnect", "ban":
+			s.peers[i].ConnectionState = 6
+		}
+		return s.peers[i], nil
+	}
+	return nil, nil
+}
+
+// handlePool returns the mining pool. A host:port parameter connects to the pool,
+// false disconnects from the pool.
+func (s *Server) handlePool(params []json.RawMessage) (interface{}, error) {
+	var pool interface{}
+	if err := decodeParams(params, 0, &pool); err != nil {
+		return nil, err
+	}
+
+	switch pool := pool.(type) {
+	case nil:
+	case string:
+		if _, _, err := net.SplitHostPort(pool); err != nil {
+			return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Invalid pool " + pool}
+		}
+		s.pool = pool
+	case bool:
+		if !pool {
+			s.pool = ""
+		}
+	default:
+		return nil, &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Invalid pool parameter"}
+	}
+
+	if s.pool == "" {
+		return nil, nil
+	}
+	return s.pool, nil
+}
+
+func (s *Server) handlePoolConfirmedBalance(params []json.RawMessage) (interface{}, error) {
+	return s.poolBalance, nil
+}
+
+func (s *Server) handlePoolConnectionState(params []json.RawMessage) (interface{}, error) {
+	if s.pool == "" {
+		return nimiqrpc.PoolConnectionStateClosed, nil
+	}
+	return nimiqrpc.PoolConnectionStateConnected, nil
+}
+
+func (s *Server) handleSendRawTransaction(params []json.RawMessage) (interface{}, error) {
+	var transactionHex string
+	if err := decodeParams(params, 1, &transactionHex); err != nil {
+		return nil, err
+	}
+
+	trn, hash, err := decodeRawTransaction(transactionHex)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendTransaction(trn, hash)
+}
+
+func (s *Server) handleSendTransaction(params []json.RawMessage) (interface{}, error) {
+	var trn nimiqrpc.OutgoingTransaction
+	if err := decodeParams(params, 1, &trn); err != nil {
+		return nil, err
+	}
+	return s.sendTransaction(trn, "")
+}
+
+func (s *Server) handleSubmitBlock(params []json.RawMessage) (interface{}, error) {
+	var fullBlock string
+	if err := decodeParams(params, 1, &fullBlock); err != nil {
+		return nil, err
+	}
+	s.mineBlock()
+	return nil, nil
+}
+
+func (s *Server) handleSyncing(params []json.RawMessage) (interface{}, error) {
+	return false, nil
+}
+
+// decodeRawTransaction decodes a transaction encoded by createRawTransaction, or a transaction that
+// is signed locally in the wire format of the network, and returns it with its hash
+func decodeRawTransaction(transactionHex string) (nimiqrpc.OutgoingTransaction, string, error) {
+	var trn nimiqrpc.OutgoingTransaction
+	invalid := &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Invalid transaction"}
+
+	raw, err := hex.DecodeString(transactionHex)
+	if err != nil {
+		return trn, "", invalid
+	}
+	if json.Unmarshal(raw, &trn) == nil {
+		hash := sha256.Sum256(raw)
+		return trn, hex.EncodeToString(hash[:]), nil
+	}
+
+	signed, err := nimiqrpc.DeserializeTransaction(raw)
+	if err == nil {
+		err = signed.Verify()
+	}
+	if err != nil {
+		return trn, "", invalid
+	}
+
+	return nimiqrpc.OutgoingTransaction{
+		From:     signed.Sender.String(),
+		FromType: signed.SenderType,
+		To:       signed.Recipient.String(),
+		ToType:   signed.RecipientType,
+		Value:    signed.Value,
+		Fee:      signed.Fee,
+		Data:     hex.EncodeToString(signed.Data),
+	}, signed.Hash(), nil
+}
@@ -0,0 +1,538 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+
+Package nimiqtest provides an in-process fake Nimiq node for testing.
+
+The Server implements the JSON-RPC methods of the Nimiq core-js node on top of an
+in-memory chain of accounts, blocks, a mempool and peers. Transactions that are sent to
+the server are added to the mempool and are applied to the accounts when a block is mined
+with MineBlock. The chain is fully deterministic, so tests can rely on the exact state.
+
+How to use this package:
+
+  srv := nimiqtest.NewServer()
+  defer srv.Close()
+
+  alice := srv.NewAccount(100000)
+  bob := srv.NewAccount(0)
+
+  client := srv.Client()
+  hash, err := client.SendTransaction(nimiqrpc.OutgoingTransaction{
+      From:  alice.Address,
+      To:    bob.Address,
+      Value: 50000,
+      Fee:   138,
+  })
+
+  // Include the transaction in a block
+  srv.MineBlock()
+
+*/
+package nimiqtest
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/redmaner/go-nimiq-rpc"
+)
+
+// JSON-RPC error codes returned by the Server
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+const (
+	genesisTimestamp = 1523412000 // timestamp of the genesis block
+	blockTime        = 60         // seconds between two blocks
+	transactionSize  = 138        // size in bytes of a basic transaction
+)
+
+// hashesPerThread is the hashrate of a single miner thread
+const hashesPerThread = 1000
+
+// defaultConstants are the default values of the constants returned by the constant method
+var defaultConstants = map[string]int{
+	"BaseConsensus.MIN_FEE_PER_BYTE":      0,
+	"Mempool.TRANSACTIONS_PER_SENDER_MAX": 500,
+	"Policy.BLOCK_TIME":                   blockTime,
+	"Policy.TRANSACTION_VALIDITY_WINDOW":  120,
+}
+
+// feeBuckets are the fee per byte buckets of the mempool, from high to low
+var feeBuckets = []int{10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1, 0}
+
+// HandlerFunc handles a JSON-RPC call. The params contain the raw JSON parameters of the call.
+// If the returned error is a *nimiqrpc.RPCError, its code, message and data are returned to
+// the client, other errors are returned as an internal error.
+type HandlerFunc func(params []json.RawMessage) (interface{}, error)
+
+// Failure describes how the Server fails an HTTP request
+type Failure struct {
+	Status int    // HTTP status code of the response
+	Body   string // body of the response
+	Block  bool   // whether the server does not respond until the request is canceled or the server is closed
+}
+
+// InterceptFunc is called for each HTTP request with the methods of the JSON-RPC calls in the
+// request. It returns the Failure of the request, or nil to handle the request.
+type InterceptFunc func(r *http.Request, methods []string) *Failure
+
+// Server is a fake Nimiq node serving JSON-RPC over HTTP
+type Server struct {
+	*httptest.Server
+
+	mu            sync.Mutex
+	handlers      map[string]HandlerFunc
+	intercept     InterceptFunc
+	requests      int           // number of received HTTP requests
+	closed        chan struct{} // closed when the server is closed
+	closeOnce     sync.Once
+	consensus     string
+	mining        bool
+	hashrate      float64
+	minerThreads  int
+	miner         nimiqrpc.Account
+	pool          string // host:port of the mining pool, empty if not connected
+	poolBalance   nimiqrpc.Luna
+	accounts      map[string]*nimiqrpc.Account // accounts by normalized user friendly address
+	ids           map[string]string            // normalized user friendly addresses by hex-encoded id
+	owned         []string                     // addresses of the accounts that are owned by the node
+	blocks        []*nimiqrpc.Block            // blocks by height, starting at the genesis block at height 1
+	txs           map[string]*nimiqrpc.Transaction
+	mempool       []*nimiqrpc.Transaction
+	peers         []nimiqrpc.Peer
+	constants     map[string]int // overridden constants by name
+	minFeePerByte nimiqrpc.Luna
+	counter       uint64 // counter used to derive deterministic addresses and hashes
+}
+
+// NewServer starts and returns a new Server with a genesis block. The server has consensus
+// established and is not mining. The caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		handlers:  make(map[string]HandlerFunc),
+		closed:    make(chan struct{}),
+		consensus: nimiqrpc.ConsensusEstablished,
+		accounts:  make(map[string]*nimiqrpc.Account),
+		ids:       make(map[string]string),
+		txs:       make(map[string]*nimiqrpc.Transaction),
+		constants: make(map[string]int),
+
+		minerThreads: 1,
+	}
+	s.miner = s.newAccount(0)
+	s.mineBlock()
+	s.registerHandlers()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a nimiqrpc.Client that is connected to the server
+func (s *Server) Client(opts ...nimiqrpc.Option) *nimiqrpc.Client {
+	return nimiqrpc.NewClient(s.URL, opts...)
+}
+
+// Handle registers the handler for the given method. It replaces the built-in handler
+// of the method, which allows scripting responses and errors.
+func (s *Server) Handle(method string, handler HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[method] = handler
+}
+
+// Intercept registers a function that is called before each HTTP request is handled, which
+// allows injecting failures like HTTP errors or a node that stops responding. Passing nil
+// removes the function.
+func (s *Server) Intercept(intercept InterceptFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.intercept = intercept
+}
+
+// Requests returns the number of HTTP requests the server received, including failed requests
+func (s *Server) Requests() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+// Close releases the requests that are blocked by a Failure and shuts down the server
+func (s *Server) Close() {
+	s.closeOnce.Do(func() {
+		close(s.closed)
+	})
+	s.Server.Close()
+}
+
+// SetConsensus sets the consensus state returned by the consensus method
+func (s *Server) SetConsensus(consensus string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.consensus = consensus
+}
+
+// SetPoolConfirmedBalance sets the balance returned by the poolConfirmedBalance method
+func (s *Server) SetPoolConfirmedBalance(balance nimiqrpc.Luna) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.poolBalance = balance
+}
+
+// AddAccount adds an account to the chain, or replaces the account with the same address
+func (s *Server) AddAccount(account nimiqrpc.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putAccount(account)
+}
+
+// NewAccount adds a new basic account with the given balance and returns it
+func (s *Server) NewAccount(balance nimiqrpc.Luna) nimiqrpc.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.newAccount(balance)
+}
+
+// Account returns the account with the given address
+func (s *Server) Account(address string) nimiqrpc.Account {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.account(address)
+}
+
+// AddPeer adds a peer that is returned by the peer methods
+func (s *Server) AddPeer(peer nimiqrpc.Peer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.peers = append(s.peers, peer)
+}
+
+// SendTransaction adds a transaction to the mempool and returns its hash
+func (s *Server) SendTransaction(trn nimiqrpc.OutgoingTransaction) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sendTransaction(trn, "")
+}
+
+// MineBlock mines a new block that includes all transactions of the mempool and returns it
+func (s *Server) MineBlock() nimiqrpc.Block {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return *s.mineBlock()
+}
+
+// MineBlocks mines n blocks
+func (s *Server) MineBlocks(n int) {
+	for i := 0; i < n; i++ {
+		s.MineBlock()
+	}
+}
+
+// BlockNumber returns the height of the most recent block
+func (s *Server) BlockNumber() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.blocks)
+}
+
+// nextHash returns a deterministic hex-encoded hash that is unique for the server
+func (s *Server) nextHash(kind string) string {
+	s.counter++
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, s.counter)
+	hash := sha256.Sum256(append([]byte(kind), buf...))
+	return hex.EncodeToString(hash[:])
+}
+
+// newAccount adds a new basic account with the given balance. s.mu must be held.
+func (s *Server) newAccount(balance nimiqrpc.Luna) nimiqrpc.Account {
+	id := s.nextHash("account")[:40]
+	account := nimiqrpc.Account{
+		ID:      id,
+		Address: nimiqrpc.MustParseAddress(id).String(),
+		Balance: balance,
+		Type:    nimiqrpc.AccountTypeBasic,
+	}
+	s.putAccount(account)
+	return account
+}
+
+// putAccount adds or replaces an account. s.mu must be held.
+func (s *Server) putAccount(account nimiqrpc.Account) {
+	key := normalize(account.Address)
+	s.accounts[key] = &account
+	if account.ID != "" {
+		s.ids[strings.ToLower(account.ID)] = key
+	}
+}
+
+// account returns the account with the given user friendly or hex-encoded address.
+// Unknown addresses result in an empty basic account. s.mu must be held.
+func (s *Server) account(address string) *nimiqrpc.Account {
+	key := normalize(address)
+	if userFriendly, ok := s.ids[strings.ToLower(address)]; ok {
+		key = userFriendly
+	}
+
+	if account, ok := s.accounts[key]; ok {
+		return account
+	}
+
+	return &nimiqrpc.Account{
+		Address: address,
+		Type:    nimiqrpc.AccountTypeBasic,
+	}
+}
+
+// sendTransaction validates a transaction and adds it to the mempool. If hash is empty, a new
+// hash is derived for the transaction. s.mu must be held.
+func (s *Server) sendTransaction(trn nimiqrpc.OutgoingTransaction, hash string) (string, error) {
+	if trn.Value <= 0 {
+		return "", &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Transaction value must be positive"}
+	}
+	if trn.Fee < s.minFeePerByte*transactionSize {
+		return "", &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Transaction fee too low"}
+	}
+	if hash == "" {
+		hash = s.nextHash("transaction")
+	}
+	if _, ok := s.txs[hash]; ok {
+		return "", &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Transaction already known"}
+	}
+
+	from := s.account(trn.From)
+	pending := nimiqrpc.Luna(0)
+	for _, tx := range s.mempool {
+		if normalize(tx.FromAddress) == normalize(from.Address) {
+			pending += tx.Value + tx.Fee
+		}
+	}
+	if from.Balance < pending+trn.Value+trn.Fee {
+		return "", &nimiqrpc.RPCError{Code: CodeInvalidParams, Message: "Insufficient funds"}
+	}
+
+	tx := s.newTransaction(trn, hash)
+	s.txs[tx.Hash] = tx
+	s.mempool = append(s.mempool, tx)
+	return tx.Hash, nil
+}
+
+// newTransaction returns the transaction object of an outgoing transaction. s.mu must be held.
+func (s *Server) newTransaction(trn nimiqrpc.OutgoingTransaction, hash string) *nimiqrpc.Transaction {
+	from := s.account(trn.From)
+	to := s.account(trn.To)
+	return &nimiqrpc.Transaction{
+		Hash:        hash,
+		From:        from.ID,
+		FromAddress: from.Address,
+		To:          to.ID,
+		ToAddress:   to.Address,
+		Value:       trn.Value,
+		Fee:         trn.Fee,
+		Data:        trn.Data,
+	}
+}
+
+// inMempool reports whether the transaction with the given hash is in the mempool. s.mu must be held.
+func (s *Server) inMempool(hash string) bool {
+	for _, tx := range s.mempool {
+		if tx.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// mineBlock mines a block including all transactions of the mempool. s.mu must be held.
+func (s *Server) mineBlock() *nimiqrpc.Block {
+	number := len(s.blocks) + 1
+	parentHash := strings.Repeat("0", 64)
+	if number > 1 {
+		parentHash = s.blocks[number-2].Hash
+	}
+
+	block := &nimiqrpc.Block{
+		Number:       number,
+		Hash:         s.nextHash("block"),
+		POW:          s.nextHash("pow"),
+		ParentHash:   parentHash,
+		BodyHash:     s.nextHash("body"),
+		AccountHash:  s.nextHash("accounts"),
+		Miner:        s.miner.ID,
+		MinerAddress: s.miner.Address,
+		Difficulty:   json.Number("1"),
+		Size:         151,
+		Timestamp:    genesisTimestamp + (number-1)*blockTime,
+	}
+
+	for i, tx := range s.mempool {
+		tx.BlockHash = block.Hash
+		tx.BlockNumber = number
+		tx.Timestamp = block.Timestamp
+		tx.TransactionIndex = i
+
+		s.account(tx.FromAddress).Balance -= tx.Value + tx.Fee
+		to := s.account(tx.ToAddress)
+		if _, ok := s.accounts[normalize(to.Address)]; !ok {
+			s.putAccount(*to)
+			to = s.account(tx.ToAddress)
+		}
+		to.Balance += tx.Value
+		s.account(s.miner.Address).Balance += tx.Fee
+
+		block.TransactionHashes = append(block.TransactionHashes, tx.Hash)
+		block.Size += transactionSize
+	}
+	s.mempool = nil
+
+	s.blocks = append(s.blocks, block)
+	return block
+}
+
+// block returns the block with the given height or nil. s.mu must be held.
+func (s *Server) block(number int) *nimiqrpc.Block {
+	if number < 1 || number > len(s.blocks) {
+		return nil
+	}
+	return s.blocks[number-1]
+}
+
+// blockByHash returns the block with the given hash or nil. s.mu must be held.
+func (s *Server) blockByHash(hash string) *nimiqrpc.Block {
+	for _, block := range s.blocks {
+		if block.Hash == hash {
+			return block
+		}
+	}
+	return nil
+}
+
+// transaction returns a copy of the transaction with the given hash, including the
+// number of confirmations, or nil. s.mu must be held.
+func (s *Server) transaction(hash string) *nimiqrpc.Transaction {
+	tx, ok := s.txs[hash]
+	if !ok {
+		return nil
+	}
+
+	result := *tx
+	if result.BlockNumber > 0 {
+		result.Confirmations = len(s.blocks) - result.BlockNumber + 1
+	}
+	return &result
+}
+
+// blockResult returns the JSON representation of a block. s.mu must be held.
+func (s *Server) blockResult(block *nimiqrpc.Block, fullTransactions bool) (interface{}, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	result := *block
+	var transactions interface{} = block.TransactionHashes
+	if fullTransactions {
+		objects := make([]nimiqrpc.Transaction, 0, len(block.TransactionHashes))
+		for _, hash := range block.TransactionHashes {
+			objects = append(objects, *s.transaction(hash))
+		}
+		transactions = objects
+	}
+	if block.TransactionHashes == nil && !fullTransactions {
+		transactions = []string{}
+	}
+
+	var err error
+	result.Transactions, err = json.Marshal(transactions)
+	return result, err
+}
+
+// mempoolResult returns the JSON representation of the mempool. s.mu must be held.
+func (s *Server) mempoolResult() interface{} {
+	counts := make(map[int]int)
+	for _, tx := range s.mempool {
+		feePerByte := int(tx.Fee) / transactionSize
+		for _, bucket := range feeBuckets {
+			if feePerByte >= bucket {
+				counts[bucket]++
+				break
+			}
+		}
+	}
+
+	buckets := []int{}
+	result := map[string]interface{}{
+		"total": len(s.mempool),
+	}
+	for _, bucket := range feeBuckets {
+		if count, ok := counts[bucket]; ok {
+			buckets = append(buckets, bucket)
+			result[fmt.Sprint(bucket)] = count
+		}
+	}
+	result["buckets"] = buckets
+	return result
+}
+
+// transactionsByAddress returns the mined transactions of an address, newest first. s.mu must be held.
+func (s *Server) transactionsByAddress(address string, maxEntries int) []nimiqrpc.Transaction {
+	key := normalize(s.account(address).Address)
+
+	var result []nimiqrpc.Transaction
+	for number := len(s.blocks); number > 0 && len(result) < maxEntries; number-- {
+		hashes := s.blocks[number-1].TransactionHashes
+		for i := len(hashes) - 1; i >= 0 && len(result) < maxEntries; i-- {
+			tx := s.transaction(hashes[i])
+			if normalize(tx.FromAddress) == key || normalize(tx.ToAddress) == key {
+				result = append(result, *tx)
+			}
+		}
+	}
+
+	if result == nil {
+		return []nimiqrpc.Transaction{}
+	}
+	return result
+}
+
+// ownedAccounts returns the accounts owned by the node, sorted by address. s.mu must be held.
+func (s *Server) ownedAccounts() []nimiqrpc.Account {
+	accounts := make([]nimiqrpc.Account, 0, len(s.owned))
+	for _, address := range s.owned {
+		accounts = append(accounts, *s.account(address))
+	}
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Address < accounts[j].Address
+	})
+	return accounts
+}
+
+// normalize returns the address without spaces in upper case
+func normalize(address string) string {
+	return strings.ToUpper(strings.Replace(address, " ", "", -1))
+}
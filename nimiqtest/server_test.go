@@ -0,0 +1,409 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqtest_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+	"github.com/ybbus/jsonrpc"
+)
+
+func TestServerTransactionFlow(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+
+	hash, err := client.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    bob.Address,
+		Value: 50000,
+		Fee:   276,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mempool, err := client.Mempool()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mempool.Total != 1 || len(mempool.Buckets) != 1 || mempool.Bucket2 != 1 {
+		t.Errorf("unexpected mempool %+v", mempool)
+	}
+
+	receipt, err := client.GetTransactionReceipt(hash)
+	if err != nil || receipt != nil {
+		t.Fatalf("expected no receipt for a pending transaction, got %+v, %v", receipt, err)
+	}
+
+	mined := srv.MineBlock()
+	srv.MineBlock()
+
+	balance, err := client.GetBalance(bob.Address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance != 50000 {
+		t.Errorf("expected balance of 50000, got %d", balance)
+	}
+	if balance := srv.Account(alice.ID).Balance; balance != 100000-50000-276 {
+		t.Errorf("unexpected sender balance %d", balance)
+	}
+
+	receipt, err = client.GetTransactionReceipt(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.BlockHash != mined.Hash || receipt.Confirmations != 2 {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
+
+	block, err := client.GetBlockByHash(mined.Hash, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Number != mined.Number || len(block.TransactionObjects) != 1 || block.TransactionObjects[0].Hash != hash {
+		t.Errorf("unexpected block %+v", block)
+	}
+
+	block, err = client.GetBlockByNumber(mined.Number+1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.ParentHash != mined.Hash || len(block.TransactionHashes) != 0 {
+		t.Errorf("unexpected block %+v", block)
+	}
+
+	transactions, err := client.GetTransactionsByAddress(bob.Address, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transactions) != 1 || transactions[0].Hash != hash {
+		t.Errorf("unexpected transactions %+v", transactions)
+	}
+}
+
+func TestServerRawTransaction(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+
+	raw, err := client.CreateRawTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    bob.Address,
+		Value: 200000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.SendRawTransaction(raw)
+	var rpcErr *nimiqrpc.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != nimiqtest.CodeInvalidParams {
+		t.Fatalf("expected insufficient funds error, got %v", err)
+	}
+}
+
+func TestServerHandle(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("hashrate", func(params []json.RawMessage) (interface{}, error) {
+		return 1234.5, nil
+	})
+	srv.Handle("peerCount", func(params []json.RawMessage) (interface{}, error) {
+		return nil, &nimiqrpc.RPCError{Code: 42, Message: "scripted error"}
+	})
+
+	client := srv.Client()
+	hashrate, err := client.Hashrate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hashrate != 1234.5 {
+		t.Errorf("unexpected hashrate %v", hashrate)
+	}
+
+	_, err = client.PeerCount()
+	var rpcErr *nimiqrpc.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != 42 {
+		t.Fatalf("expected scripted error, got %v", err)
+	}
+
+	resps, err := client.CallBatch(nimiqrpc.NewRequest("blockNumber"), nimiqrpc.NewRequest("unknownMethod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resps.GetByID(0).Error != nil || resps.GetByID(1).Error.Code != nimiqtest.CodeMethodNotFound {
+		t.Errorf("unexpected batch responses %+v %+v", resps[0], resps[1])
+	}
+}
+
+func TestServerIntercept(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	var mu sync.Mutex
+	var calls [][]string
+	srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		mu.Lock()
+		calls = append(calls, methods)
+		mu.Unlock()
+		if methods[0] == "peerCount" {
+			return &nimiqtest.Failure{Status: http.StatusServiceUnavailable}
+		}
+		if methods[0] == "hashrate" {
+			return &nimiqtest.Failure{Block: true}
+		}
+		return nil
+	})
+
+	if _, err := client.CallBatch(nimiqrpc.NewRequest("blockNumber"), nimiqrpc.NewRequest("consensus")); err != nil {
+		t.Fatal(err)
+	}
+	_, err := client.PeerCount()
+	var httpErr *jsonrpc.HTTPError
+	if !errors.As(err, &httpErr) || httpErr.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 Service Unavailable, got %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := client.HashrateContext(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := [][]string{{"blockNumber", "consensus"}, {"peerCount"}, {"hashrate"}}
+	if !reflect.DeepEqual(calls, expected) || srv.Requests() != 3 {
+		t.Errorf("unexpected requests %v, %d", calls, srv.Requests())
+	}
+}
+
+func TestServerMempoolContent(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	alice := srv.NewAccount(100000)
+	bob := srv.NewAccount(0)
+
+	raw, err := client.CreateRawTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    bob.Address,
+		Value: 50000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := client.GetRawTransactionInfo(raw)
+	if err != nil || !info.Valid || info.InMempool || info.Value != 50000 {
+		t.Fatalf("unexpected raw transaction info %+v, %v", info, err)
+	}
+
+	hash, err := client.SendRawTransaction(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != info.Hash {
+		t.Errorf("expected hash %s, got %s", info.Hash, hash)
+	}
+
+	info, err = client.GetRawTransactionInfo(raw)
+	if err != nil || !info.InMempool {
+		t.Errorf("expected transaction in mempool, got %+v, %v", info, err)
+	}
+
+	content, err := client.MempoolContent(false)
+	if err != nil || len(content.TransactionHashes) != 1 || content.TransactionHashes[0] != hash {
+		t.Errorf("unexpected mempool content %+v, %v", content, err)
+	}
+	content, err = client.MempoolContent(true)
+	if err != nil || len(content.TransactionObjects) != 1 || content.TransactionObjects[0].Hash != hash {
+		t.Errorf("unexpected mempool content %+v, %v", content, err)
+	}
+
+	srv.MineBlock()
+	content, err = client.MempoolContent(false)
+	if err != nil || len(content.TransactionHashes) != 0 {
+		t.Errorf("expected empty mempool, got %+v, %v", content, err)
+	}
+}
+
+func TestServerMinFeePerByte(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	fee, err := client.SetMinFeePerByte(2)
+	if err != nil || fee != 2 {
+		t.Fatalf("SetMinFeePerByte: got %d, %v", fee, err)
+	}
+	if fee, err = client.MinFeePerByte(); err != nil || fee != 2 {
+		t.Fatalf("MinFeePerByte: got %d, %v", fee, err)
+	}
+
+	alice := srv.NewAccount(100000)
+	_, err = client.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    alice.Address,
+		Value: 1,
+		Fee:   138,
+	})
+	var rpcErr *nimiqrpc.RPCError
+	if !errors.As(err, &rpcErr) {
+		t.Errorf("expected fee too low error, got %v", err)
+	}
+}
+
+func TestServerConstant(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	const constant = "Policy.BLOCK_TIME"
+	if value, err := client.Constant(constant); err != nil || value != 60 {
+		t.Fatalf("Constant: got %d, %v", value, err)
+	}
+	if value, err := client.SetConstant(constant, 10); err != nil || value != 10 {
+		t.Fatalf("SetConstant: got %d, %v", value, err)
+	}
+	if value, err := client.Constant(constant); err != nil || value != 10 {
+		t.Fatalf("Constant after SetConstant: got %d, %v", value, err)
+	}
+	if value, err := client.ResetConstant(constant); err != nil || value != 60 {
+		t.Fatalf("ResetConstant: got %d, %v", value, err)
+	}
+
+	var rpcErr *nimiqrpc.RPCError
+	if _, err := client.Constant("Unknown.CONSTANT"); !errors.As(err, &rpcErr) {
+		t.Errorf("expected error for unknown constant, got %v", err)
+	}
+}
+
+func TestServerMinerControl(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if threads, err := client.SetMinerThreads(4); err != nil || threads != 4 {
+		t.Fatalf("SetMinerThreads: got %d, %v", threads, err)
+	}
+	if mining, err := client.SetMining(true); err != nil || !mining {
+		t.Fatalf("SetMining: got %v, %v", mining, err)
+	}
+	if hashrate, err := client.Hashrate(); err != nil || hashrate == 0 {
+		t.Errorf("expected positive hashrate while mining, got %v, %v", hashrate, err)
+	}
+	if mining, err := client.SetMining(false); err != nil || mining {
+		t.Errorf("SetMining: got %v, %v", mining, err)
+	}
+	if address, err := client.MinerAddress(); err != nil || address == "" {
+		t.Errorf("MinerAddress: got %q, %v", address, err)
+	}
+}
+
+func TestServerPool(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if state, err := client.PoolConnectionState(); err != nil || state != nimiqrpc.PoolConnectionStateClosed {
+		t.Fatalf("PoolConnectionState: got %v, %v", state, err)
+	}
+	if pool, err := client.SetPool("pool.nimiq.watch:8443"); err != nil || pool != "pool.nimiq.watch:8443" {
+		t.Fatalf("SetPool: got %q, %v", pool, err)
+	}
+	if state, err := client.PoolConnectionState(); err != nil || state != nimiqrpc.PoolConnectionStateConnected {
+		t.Errorf("PoolConnectionState: got %v, %v", state, err)
+	}
+
+	srv.SetPoolConfirmedBalance(5000)
+	if balance, err := client.PoolConfirmedBalance(); err != nil || balance != 5000 {
+		t.Errorf("PoolConfirmedBalance: got %d, %v", balance, err)
+	}
+
+	if err := client.DisconnectPool(); err != nil {
+		t.Fatal(err)
+	}
+	if pool, err := client.Pool(); err != nil || pool != "" {
+		t.Errorf("Pool after DisconnectPool: got %q, %v", pool, err)
+	}
+}
+
+func TestServerSignedTransaction(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	kp, err := nimiqrpc.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wallet := kp.Wallet()
+	srv.AddAccount(nimiqrpc.Account{ID: wallet.ID, Address: wallet.Address, Balance: 100000})
+	bob := srv.NewAccount(0)
+
+	trn := nimiqrpc.OutgoingTransaction{From: wallet.Address, To: bob.Address, Value: 50000, Fee: 138}
+	raw, err := nimiqrpc.NewRawTransaction(trn, uint32(srv.BlockNumber()), nimiqrpc.NetworkIDDummy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := raw.Sign(kp); err != nil {
+		t.Fatal(err)
+	}
+	signed, err := raw.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hash, err := client.SendRawTransaction(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != raw.Hash() {
+		t.Errorf("expected hash %s, got %s", raw.Hash(), hash)
+	}
+
+	srv.MineBlock()
+	if balance := srv.Account(bob.Address).Balance; balance != 50000 {
+		t.Errorf("expected balance of 50000, got %d", balance)
+	}
+
+	raw.Value = 60000
+	tampered, _ := raw.Hex()
+	var rpcErr *nimiqrpc.RPCError
+	if _, err := client.SendRawTransaction(tampered); !errors.As(err, &rpcErr) || rpcErr.Code != nimiqtest.CodeInvalidParams {
+		t.Errorf("expected invalid transaction error, got %v", err)
+	}
+}
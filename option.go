@@ -0,0 +1,79 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Option configures a Client. Options are passed to NewClient and applied in order,
+// so a later option overrides an earlier one.
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client that is used to send requests to the node.
+// This can be used to set a custom transport, proxy or TLS configuration.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(nc *Client) {
+		if httpClient != nil {
+			nc.httpClient = httpClient
+		}
+	}
+}
+
+// WithTimeout sets the default timeout of a single RPC call. The timeout is applied on top
+// of the context that is passed to a call, so a context with an earlier deadline still takes
+// precedence. A timeout of zero means no timeout.
+func WithTimeout(timeout time.Duration) Option {
+	return func(nc *Client) {
+		nc.timeout = timeout
+	}
+}
+
+// WithPollInterval sets the interval at which the node is polled by methods that wait for
+// changes on the chain, like WaitForConfirmations. Defaults to 5 seconds.
+func WithPollInterval(interval time.Duration) Option {
+	return func(nc *Client) {
+		nc.pollInterval = interval
+	}
+}
+
+// WithHeader sets a custom HTTP header that is sent with every request.
+func WithHeader(key, value string) Option {
+	return func(nc *Client) {
+		nc.headers[key] = value
+	}
+}
+
+// WithHeaders sets custom HTTP headers that are sent with every request.
+func WithHeaders(headers map[string]string) Option {
+	return func(nc *Client) {
+		for key, value := range headers {
+			nc.headers[key] = value
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header that is sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithBasicAuth sets the username and password that are used to authenticate to the node.
+func WithBasicAuth(username, password string) Option {
+	return WithHeader("Authorization", fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password)))))
+}
@@ -0,0 +1,483 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// ErrInvalidTransaction is returned when a transaction cannot be built, signed or serialized
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+// Network IDs of the Nimiq networks, which are part of every transaction to prevent
+// replaying it on another network
+const (
+	NetworkIDMain  NetworkID = 42
+	NetworkIDTest  NetworkID = 1
+	NetworkIDDev   NetworkID = 2
+	NetworkIDDummy NetworkID = 4
+)
+
+// Transaction flags
+const (
+	// TransactionFlagContractCreation marks a transaction that creates a contract with
+	// the parameters in its data. The recipient of such a transaction is the address of the
+	// contract, which is derived from the transaction itself, so constructors like
+	// HTLC.OutgoingTransaction set the zero address as a placeholder recipient. These
+	// transactions must be signed locally with NewRawTransaction; SendTransaction and
+	// CreateRawTransaction reject them, as the node would pay the zero address instead.
+	TransactionFlagContractCreation = 0x1
+)
+
+// Serialization formats of transactions
+const (
+	transactionFormatBasic    = 0
+	transactionFormatExtended = 1
+)
+
+// signatureProofSize is the size of a signature proof with an empty Merkle path:
+// a public key, the number of Merkle path nodes and a signature
+const signatureProofSize = ed25519.PublicKeySize + 1 + ed25519.SignatureSize
+
+// NetworkID identifies a Nimiq network
+type NetworkID uint8
+
+// RawTransaction is a transaction that is built and signed locally. Its serialization is the
+// hex-encoded transaction accepted by SendRawTransaction, so private keys never leave the process.
+//
+// A transaction between basic accounts without data and flags is serialized in the compact basic
+// format, any other transaction in the extended format.
+type RawTransaction struct {
+	Sender              Address
+	SenderType          AccountType
+	Recipient           Address
+	RecipientType       AccountType
+	Value               Luna
+	Fee                 Luna
+	ValidityStartHeight uint32 // height from which on the transaction is valid
+	NetworkID           NetworkID
+	Flags               uint8
+	Data                []byte // contract parameters or a message
+	Proof               []byte // signature proof, set by Sign
+}
+
+// NewRawTransaction returns an unsigned transaction for trn, which is valid from the given
+// validity start height on the given network. For a transaction with TransactionFlagContractCreation,
+// trn.To is ignored and the recipient is set to the address of the created contract.
+func NewRawTransaction(trn OutgoingTransaction, validityStartHeight uint32, networkID NetworkID) (*RawTransaction, error) {
+	sender, err := trn.ParsedFrom()
+	if err != nil {
+		return nil, fmt.Errorf("%w: sender: %v", ErrInvalidTransaction, err)
+	}
+	var recipient Address
+	if trn.Flags&TransactionFlagContractCreation == 0 {
+		recipient, err = trn.ParsedTo()
+		if err != nil {
+			return nil, fmt.Errorf("%w: recipient: %v", ErrInvalidTransaction, err)
+		}
+	}
+	data, err := hex.DecodeString(trn.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data: %v", ErrInvalidTransaction, err)
+	}
+
+	t := &RawTransaction{
+		Sender:              sender,
+		SenderType:          trn.FromType,
+		Recipient:           recipient,
+		RecipientType:       trn.ToType,
+		Value:               trn.Value,
+		Fee:                 trn.Fee,
+		ValidityStartHeight: validityStartHeight,
+		NetworkID:           networkID,
+		Flags:               uint8(trn.Flags),
+		Data:                data,
+	}
+	if t.Flags&TransactionFlagContractCreation != 0 {
+		t.Recipient = t.ContractCreationAddress()
+	}
+	return t, nil
+}
+
+// contractCreation returns the transaction that creates a contract of the given type with data,
+// funded with value by from. Its recipient is a placeholder that NewRawTransaction replaces by the
+// address of the contract.
+func contractCreation(toType AccountType, from Address, value, fee Luna, data []byte) OutgoingTransaction {
+	return OutgoingTransaction{
+		From:   from.String(),
+		To:     Address{}.String(),
+		ToType: toType,
+		Value:  value,
+		Fee:    fee,
+		Data:   hex.EncodeToString(data),
+		Flags:  TransactionFlagContractCreation,
+	}
+}
+
+// checkRemoteTransaction returns an error if trn cannot be built by the node, which is the case for
+// a contract creation with a placeholder recipient
+func checkRemoteTransaction(trn OutgoingTransaction) error {
+	if trn.Flags&TransactionFlagContractCreation == 0 {
+		return nil
+	}
+	if trn.To == "" {
+		return fmt.Errorf("%w: contract creation without recipient must be signed with NewRawTransaction", ErrInvalidTransaction)
+	}
+	if to, err := trn.ParsedTo(); err == nil && to.IsZero() {
+		return fmt.Errorf("%w: contract creation to the zero address must be signed with NewRawTransaction", ErrInvalidTransaction)
+	}
+	return nil
+}
+
+// SignTransaction builds the transaction for trn, signs it with kp and returns the hex-encoded
+// transaction that can be sent with SendRawTransaction
+func SignTransaction(trn OutgoingTransaction, validityStartHeight uint32, networkID NetworkID, kp *KeyPair) (string, error) {
+	raw, err := NewRawTransaction(trn, validityStartHeight, networkID)
+	if err != nil {
+		return "", err
+	}
+	err = raw.Sign(kp)
+	if err != nil {
+		return "", err
+	}
+	return raw.Hex()
+}
+
+// SerializeContent returns the content of the transaction, which is the message that is signed
+func (t *RawTransaction) SerializeContent() []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint16(len(t.Data)))
+	buf.Write(t.Data)
+	buf.Write(t.Sender[:])
+	buf.WriteByte(byte(t.SenderType))
+	buf.Write(t.Recipient[:])
+	buf.WriteByte(byte(t.RecipientType))
+	binary.Write(&buf, binary.BigEndian, uint64(t.Value))
+	binary.Write(&buf, binary.BigEndian, uint64(t.Fee))
+	binary.Write(&buf, binary.BigEndian, t.ValidityStartHeight)
+	buf.WriteByte(byte(t.NetworkID))
+	buf.WriteByte(t.Flags)
+	return buf.Bytes()
+}
+
+// ContractCreationAddress returns the address of the contract that is created by the transaction,
+// which is derived from the hash of the transaction with an empty recipient
+func (t *RawTransaction) ContractCreationAddress() Address {
+	creation := *t
+	creation.Recipient = Address{}
+	hash := blake2b.Sum256(creation.SerializeContent())
+
+	var address Address
+	copy(address[:], hash[:])
+	return address
+}
+
+// SignatureProof returns the proof of the signature of the transaction by kp
+func (t *RawTransaction) SignatureProof(kp *KeyPair) []byte {
+	return SignatureProof(kp.PublicKey(), kp.Sign(t.SerializeContent()))
+}
+
+// Sign signs the transaction with kp and sets its signature proof. The key pair must belong to the
+// sender of a transaction from a basic account, and to the owner of a vesting contract for a withdrawal
+// from the contract. HTLCs require a proof that contains the signatures, so transactions from HTLCs
+// are signed with SignatureProof, and the proof is built with HTLCRegularTransferProof,
+// HTLCEarlyResolveProof or HTLCTimeoutResolveProof.
+func (t *RawTransaction) Sign(kp *KeyPair) error {
+	switch {
+	case t.SenderType == AccountTypeHTLC:
+		return fmt.Errorf("%w: transactions of HTLC senders must be signed with an HTLC proof", ErrInvalidTransaction)
+	case t.SenderType == AccountTypeBasic && kp.Address() != t.Sender:
+		return fmt.Errorf("%w: key pair of %s cannot sign for sender %s", ErrInvalidTransaction, kp.Address(), t.Sender)
+	}
+
+	t.Proof = t.SignatureProof(kp)
+	return nil
+}
+
+// SignatureProof returns the proof of a single signature, which consists of the public key,
+// an empty Merkle path and the signature
+func SignatureProof(publicKey ed25519.PublicKey, signature []byte) []byte {
+	proof := make([]byte, 0, signatureProofSize)
+	proof = append(proof, publicKey...)
+	proof = append(proof, 0)
+	return append(proof, signature...)
+}
+
+// IsBasic reports whether the transaction is serialized in the basic format
+func (t *RawTransaction) IsBasic() bool {
+	return t.SenderType == AccountTypeBasic && t.RecipientType == AccountTypeBasic &&
+		len(t.Data) == 0 && t.Flags == 0 &&
+		len(t.Proof) == signatureProofSize && t.Proof[ed25519.PublicKeySize] == 0
+}
+
+// Serialize returns the transaction in the wire format of the network
+func (t *RawTransaction) Serialize() ([]byte, error) {
+	switch {
+	case t.Proof == nil:
+		return nil, fmt.Errorf("%w: transaction is not signed", ErrInvalidTransaction)
+	case t.Value < 0 || t.Fee < 0:
+		return nil, fmt.Errorf("%w: negative value or fee", ErrInvalidTransaction)
+	case len(t.Data) > math.MaxUint16:
+		return nil, fmt.Errorf("%w: data exceeds %d bytes", ErrInvalidTransaction, math.MaxUint16)
+	case len(t.Proof) > math.MaxUint16:
+		return nil, fmt.Errorf("%w: proof exceeds %d bytes", ErrInvalidTransaction, math.MaxUint16)
+	}
+
+	var buf bytes.Buffer
+	if t.IsBasic() {
+		buf.WriteByte(transactionFormatBasic)
+		buf.Write(t.Proof[:ed25519.PublicKeySize])
+		buf.Write(t.Recipient[:])
+		binary.Write(&buf, binary.BigEndian, uint64(t.Value))
+		binary.Write(&buf, binary.BigEndian, uint64(t.Fee))
+		binary.Write(&buf, binary.BigEndian, t.ValidityStartHeight)
+		buf.WriteByte(byte(t.NetworkID))
+		buf.Write(t.Proof[ed25519.PublicKeySize+1:])
+		return buf.Bytes(), nil
+	}
+
+	buf.WriteByte(transactionFormatExtended)
+	buf.Write(t.SerializeContent())
+	binary.Write(&buf, binary.BigEndian, uint16(len(t.Proof)))
+	buf.Write(t.Proof)
+	return buf.Bytes(), nil
+}
+
+// Hex returns the hex-encoded serialized transaction
+func (t *RawTransaction) Hex() (string, error) {
+	raw, err := t.Serialize()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
+
+// DecodeRawTransaction decodes a hex-encoded transaction in the basic or extended format, like
+// the transactions returned by CreateRawTransaction or SignTransaction
+func DecodeRawTransaction(transactionHex string) (*RawTransaction, error) {
+	raw, err := hex.DecodeString(transactionHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+	return DeserializeTransaction(raw)
+}
+
+// DeserializeTransaction decodes a transaction in the basic or extended format
+func DeserializeTransaction(raw []byte) (*RawTransaction, error) {
+	r := bytes.NewReader(raw)
+	format, err := r.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("%w: empty transaction", ErrInvalidTransaction)
+	}
+
+	var t *RawTransaction
+	switch format {
+	case transactionFormatBasic:
+		t, err = deserializeBasic(r)
+	case transactionFormatExtended:
+		t, err = deserializeExtended(r)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %d", ErrInvalidTransaction, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrInvalidTransaction, r.Len())
+	}
+	return t, nil
+}
+
+// deserializeBasic decodes a transaction in the basic format, without the format byte
+func deserializeBasic(r *bytes.Reader) (*RawTransaction, error) {
+	var basic struct {
+		PublicKey           [ed25519.PublicKeySize]byte
+		Recipient           Address
+		Value               uint64
+		Fee                 uint64
+		ValidityStartHeight uint32
+		NetworkID           NetworkID
+		Signature           [ed25519.SignatureSize]byte
+	}
+	err := binary.Read(r, binary.BigEndian, &basic)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawTransaction{
+		Sender:              AddressFromPublicKey(basic.PublicKey[:]),
+		SenderType:          AccountTypeBasic,
+		Recipient:           basic.Recipient,
+		RecipientType:       AccountTypeBasic,
+		Value:               Luna(basic.Value),
+		Fee:                 Luna(basic.Fee),
+		ValidityStartHeight: basic.ValidityStartHeight,
+		NetworkID:           basic.NetworkID,
+		Proof:               SignatureProof(basic.PublicKey[:], basic.Signature[:]),
+	}, nil
+}
+
+// deserializeExtended decodes a transaction in the extended format, without the format byte
+func deserializeExtended(r *bytes.Reader) (*RawTransaction, error) {
+	data, err := readVarBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var content struct {
+		Sender              Address
+		SenderType          uint8
+		Recipient           Address
+		RecipientType       uint8
+		Value               uint64
+		Fee                 uint64
+		ValidityStartHeight uint32
+		NetworkID           NetworkID
+		Flags               uint8
+	}
+	err = binary.Read(r, binary.BigEndian, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := readVarBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawTransaction{
+		Sender:              content.Sender,
+		SenderType:          AccountType(content.SenderType),
+		Recipient:           content.Recipient,
+		RecipientType:       AccountType(content.RecipientType),
+		Value:               Luna(content.Value),
+		Fee:                 Luna(content.Fee),
+		ValidityStartHeight: content.ValidityStartHeight,
+		NetworkID:           content.NetworkID,
+		Flags:               content.Flags,
+		Data:                data,
+		Proof:               proof,
+	}, nil
+}
+
+// readVarBytes reads a byte slice that is prefixed by its length as uint16
+func readVarBytes(r *bytes.Reader) ([]byte, error) {
+	var length uint16
+	err := binary.Read(r, binary.BigEndian, &length)
+	if err != nil {
+		return nil, err
+	}
+
+	b := make([]byte, length)
+	_, err = io.ReadFull(r, b)
+	return b, err
+}
+
+// TransactionHash returns the hash of a hex-encoded transaction in the basic or extended format.
+// The hash equals the hash that is returned by SendRawTransaction, so it can be recorded before the
+// transaction is sent. Since the signature proof is not part of the hash, sending the same transaction
+// again results in the same hash.
+func TransactionHash(transactionHex string) (string, error) {
+	t, err := DecodeRawTransaction(transactionHex)
+	if err != nil {
+		return "", err
+	}
+	return t.Hash(), nil
+}
+
+// Hash returns the hex-encoded hash of the transaction, which is the Blake2b hash of its content
+func (t *RawTransaction) Hash() string {
+	hash := blake2b.Sum256(t.SerializeContent())
+	return hex.EncodeToString(hash[:])
+}
+
+// Verify verifies the signature proof of the transaction. For transactions from basic accounts it also
+// verifies that the proof belongs to the sender. Transactions from HTLC accounts cannot be verified.
+func (t *RawTransaction) Verify() error {
+	if t.SenderType == AccountTypeHTLC {
+		return fmt.Errorf("%w: proofs of HTLC senders cannot be verified", ErrInvalidTransaction)
+	}
+
+	publicKey, root, signature, err := parseSignatureProof(t.Proof)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTransaction, err)
+	}
+
+	// The address of a basic account is the root of the Merkle path of the signer,
+	// which is the hash of its public key for a single signer
+	if t.SenderType == AccountTypeBasic && !bytes.Equal(root[:len(t.Sender)], t.Sender[:]) {
+		return fmt.Errorf("%w: proof does not belong to sender %s", ErrInvalidTransaction, t.Sender)
+	}
+	if !ed25519.Verify(publicKey, t.SerializeContent(), signature) {
+		return fmt.Errorf("%w: invalid signature", ErrInvalidTransaction)
+	}
+	return nil
+}
+
+// parseSignatureProof parses a signature proof and returns the public key, the root of its
+// Merkle path and the signature
+func parseSignatureProof(proof []byte) (ed25519.PublicKey, []byte, []byte, error) {
+	r := bytes.NewReader(proof)
+
+	publicKey := make([]byte, ed25519.PublicKeySize)
+	_, err := io.ReadFull(r, publicKey)
+	if err != nil {
+		return nil, nil, nil, errors.New("truncated signature proof")
+	}
+
+	// The Merkle path consists of the number of nodes, a bit for every node whether it is
+	// the left node, and the hashes of the nodes
+	count, err := r.ReadByte()
+	if err != nil {
+		return nil, nil, nil, errors.New("truncated signature proof")
+	}
+	left := make([]byte, (int(count)+7)/8)
+	_, err = io.ReadFull(r, left)
+	if err != nil {
+		return nil, nil, nil, errors.New("truncated signature proof")
+	}
+
+	root := blake2b.Sum256(publicKey)
+	for i := 0; i < int(count); i++ {
+		node := make([]byte, blake2b.Size256)
+		_, err = io.ReadFull(r, node)
+		if err != nil {
+			return nil, nil, nil, errors.New("truncated signature proof")
+		}
+
+		if left[i/8]&(0x80>>uint(i%8)) != 0 {
+			root = blake2b.Sum256(append(node, root[:]...))
+		} else {
+			root = blake2b.Sum256(append(root[:], node...))
+		}
+	}
+
+	signature := make([]byte, ed25519.SignatureSize)
+	_, err = io.ReadFull(r, signature)
+	if err != nil || r.Len() > 0 {
+		return nil, nil, nil, errors.New("signature proof has an invalid length")
+	}
+
+	return publicKey, root[:], signature, nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// testKeyPair returns the key pair of test vector 1 of RFC 8032
+func testKeyPair(t *testing.T) *KeyPair {
+	kp, err := ParsePrivateKey("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kp
+}
+
+func TestRawTransactionBasic(t *testing.T) {
+	kp := testKeyPair(t)
+	recipient := MustParseAddress("NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP")
+
+	raw, err := NewRawTransaction(OutgoingTransaction{
+		From:  kp.Address().String(),
+		To:    recipient.Hex(),
+		Value: 100000,
+		Fee:   138,
+	}, 1000, NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := raw.Serialize(); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for unsigned transaction, got %v", err)
+	}
+	if err := raw.Sign(testKeyPairOf(t, 1)); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction when signing with another key, got %v", err)
+	}
+	if err := raw.Sign(kp); err != nil {
+		t.Fatal(err)
+	}
+	if !raw.IsBasic() {
+		t.Fatal("expected basic transaction")
+	}
+
+	serialized, err := raw.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "00" +
+		hex.EncodeToString(kp.PublicKey()) +
+		recipient.Hex() +
+		"00000000000186a0" + // value
+		"000000000000008a" + // fee
+		"000003e8" + // validity start height
+		"01" // network ID
+	if len(serialized) != 138 || hex.EncodeToString(serialized[:74]) != expected {
+		t.Fatalf("unexpected serialization %x", serialized)
+	}
+	if !ed25519.Verify(kp.PublicKey(), raw.SerializeContent(), serialized[74:]) {
+		t.Error("expected valid signature")
+	}
+
+	signed, err := SignTransaction(OutgoingTransaction{
+		From:  kp.Address().String(),
+		To:    recipient.Hex(),
+		Value: 100000,
+		Fee:   138,
+	}, 1000, NetworkIDTest, kp)
+	if err != nil || signed != hex.EncodeToString(serialized) {
+		t.Errorf("SignTransaction: got %s, %v", signed, err)
+	}
+}
+
+func TestRawTransactionExtended(t *testing.T) {
+	kp := testKeyPair(t)
+	recipient := MustParseAddress("NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP")
+
+	raw, err := NewRawTransaction(OutgoingTransaction{
+		From:   kp.Address().Hex(),
+		To:     recipient.String(),
+		ToType: AccountTypeHTLC,
+		Value:  5,
+		Fee:    0,
+		Data:   "cafe",
+	}, 7, NetworkIDMain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := raw.Sign(kp); err != nil {
+		t.Fatal(err)
+	}
+	if raw.IsBasic() {
+		t.Fatal("expected extended transaction")
+	}
+
+	serialized, err := raw.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := "0002cafe" + // data
+		kp.Address().Hex() + "00" + // sender
+		recipient.Hex() + "02" + // recipient
+		"0000000000000005" + // value
+		"0000000000000000" + // fee
+		"00000007" + // validity start height
+		"2a" + // network ID
+		"00" // flags
+	if hex.EncodeToString(raw.SerializeContent()) != content {
+		t.Fatalf("unexpected content %x", raw.SerializeContent())
+	}
+
+	proof := SignatureProof(kp.PublicKey(), kp.Sign(raw.SerializeContent()))
+	expected := append(append(append([]byte{1}, raw.SerializeContent()...), 0, 97), proof...)
+	if !bytes.Equal(serialized, expected) {
+		t.Errorf("unexpected serialization %x", serialized)
+	}
+
+	for _, trn := range []OutgoingTransaction{
+		{From: "NQ00", To: recipient.Hex()},
+		{From: kp.Address().Hex(), To: "invalid"},
+		{From: kp.Address().Hex(), To: recipient.Hex(), Data: "xyz"},
+	} {
+		if _, err := NewRawTransaction(trn, 1, NetworkIDMain); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("expected ErrInvalidTransaction for %+v, got %v", trn, err)
+		}
+	}
+}
+
+// testKeyPairOf returns a deterministic key pair with a seed of the given byte
+func testKeyPairOf(t *testing.T, b byte) *KeyPair {
+	kp, err := GenerateKeyPairFrom(bytes.NewReader(bytes.Repeat([]byte{b}, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return kp
+}
+
+func TestDecodeRawTransaction(t *testing.T) {
+	kp := testKeyPair(t)
+	recipient := MustParseAddress("NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP")
+
+	for _, trn := range []OutgoingTransaction{
+		{From: kp.Address().Hex(), To: recipient.Hex(), Value: 100000, Fee: 138},
+		{From: kp.Address().Hex(), To: recipient.Hex(), Value: 100000, Fee: 138, Data: "6e696d6971"},
+	} {
+		signed, err := SignTransaction(trn, 1000, NetworkIDTest, kp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		raw, err := DecodeRawTransaction(signed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if raw.Sender != kp.Address() || raw.Recipient != recipient || raw.Value != 100000 || raw.Fee != 138 ||
+			raw.ValidityStartHeight != 1000 || raw.NetworkID != NetworkIDTest || hex.EncodeToString(raw.Data) != trn.Data {
+			t.Errorf("unexpected transaction %+v", raw)
+		}
+		if err := raw.Verify(); err != nil {
+			t.Errorf("Verify: %v", err)
+		}
+		if reencoded, err := raw.Hex(); err != nil || reencoded != signed {
+			t.Errorf("expected %s after decoding, got %s, %v", signed, reencoded, err)
+		}
+
+		raw.Value++
+		if err := raw.Verify(); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("expected invalid signature of modified transaction, got %v", err)
+		}
+		if _, err := DecodeRawTransaction(signed + "00"); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("expected ErrInvalidTransaction for trailing bytes, got %v", err)
+		}
+		if _, err := DecodeRawTransaction(signed[:len(signed)-2]); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("expected ErrInvalidTransaction for truncated transaction, got %v", err)
+		}
+	}
+
+	for _, invalid := range []string{"", "zz", "02", "01"} {
+		if _, err := DecodeRawTransaction(invalid); !errors.Is(err, ErrInvalidTransaction) {
+			t.Errorf("DecodeRawTransaction(%q): expected ErrInvalidTransaction, got %v", invalid, err)
+		}
+	}
+}
+
+func TestRawTransactionVerify(t *testing.T) {
+	kp := testKeyPair(t)
+	other := testKeyPairOf(t, 1)
+
+	raw := &RawTransaction{
+		Sender:    other.Address(),
+		Recipient: kp.Address(),
+		Value:     1,
+		NetworkID: NetworkIDMain,
+	}
+	raw.Proof = SignatureProof(kp.PublicKey(), kp.Sign(raw.SerializeContent()))
+	if err := raw.Verify(); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected proof of other sender to be rejected, got %v", err)
+	}
+
+	// The owner of a vesting contract signs for the contract
+	raw.SenderType = AccountTypeVesting
+	raw.Proof = SignatureProof(kp.PublicKey(), kp.Sign(raw.SerializeContent()))
+	if err := raw.Verify(); err != nil {
+		t.Errorf("Verify of vesting sender: %v", err)
+	}
+
+	// A signer of a multisig account proves its public key is a leaf of the Merkle tree of the account
+	node := bytes.Repeat([]byte{7}, 32)
+	leaf := blake2bSum(kp.PublicKey())
+	root := blake2bSum(append(append([]byte{}, node...), leaf...))
+	raw.SenderType = AccountTypeBasic
+	copy(raw.Sender[:], root)
+	signature := kp.Sign(raw.SerializeContent())
+	raw.Proof = append(append(append(append([]byte{}, kp.PublicKey()...), 1, 0x80), node...), signature...)
+	if err := raw.Verify(); err != nil {
+		t.Errorf("Verify of multisig sender: %v", err)
+	}
+	raw.Proof[ed25519.PublicKeySize+1] = 0
+	if err := raw.Verify(); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected Merkle path with wrong direction to be rejected, got %v", err)
+	}
+
+	raw.SenderType = AccountTypeHTLC
+	if err := raw.Verify(); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected HTLC sender to be rejected, got %v", err)
+	}
+}
+
+func blake2bSum(b []byte) []byte {
+	hash := blake2b.Sum256(b)
+	return hash[:]
+}
+
+// TestTransactionHash checks the fixtures in testdata. They are not transactions of a node: they were
+// generated with a separate implementation of the serialization, Blake2b and Ed25519, see
+// testdata/README.md. TestNodeTransactionHash checks the same transactions against a node, and
+// transactions of a node should be added with the hash of getTransactionByHash.
+func TestTransactionHash(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/transactions.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixtures []struct {
+		Name        string `json:"name"`
+		Network     string `json:"network"`
+		Transaction string `json:"transaction"`
+		Hash        string `json:"hash"`
+		Contract    string `json:"contract"` // hex-encoded address of a created contract
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatal(err)
+	}
+	networks := map[string]NetworkID{"main": NetworkIDMain, "test": NetworkIDTest, "dev": NetworkIDDev}
+
+	for _, fixture := range fixtures {
+		hash, err := TransactionHash(fixture.Transaction)
+		if err != nil {
+			t.Errorf("%s: %v", fixture.Name, err)
+			continue
+		}
+		if hash != fixture.Hash {
+			t.Errorf("%s: expected hash %s, got %s", fixture.Name, fixture.Hash, hash)
+		}
+
+		raw, _ := DecodeRawTransaction(fixture.Transaction)
+		if reencoded, err := raw.Hex(); err != nil || reencoded != fixture.Transaction {
+			t.Errorf("%s: expected %s after decoding, got %s, %v", fixture.Name, fixture.Transaction, reencoded, err)
+		}
+		if raw.NetworkID != networks[fixture.Network] {
+			t.Errorf("%s: expected network %s, got %d", fixture.Name, fixture.Network, raw.NetworkID)
+		}
+		if err := raw.Verify(); err != nil {
+			t.Errorf("%s: %v", fixture.Name, err)
+		}
+		if fixture.Contract != "" {
+			if raw.Recipient.Hex() != fixture.Contract || raw.ContractCreationAddress().Hex() != fixture.Contract {
+				t.Errorf("%s: expected contract %s, got recipient %s", fixture.Name, fixture.Contract, raw.Recipient.Hex())
+			}
+		}
+	}
+
+	if _, err := TransactionHash("00"); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction, got %v", err)
+	}
+}
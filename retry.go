@@ -0,0 +1,217 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/ybbus/jsonrpc"
+)
+
+// nonIdempotentMethods contains the RPC methods that change state on the node or the network
+// each time they are called. These are never retried, unless explicitly allowed by the RetryPolicy.
+var nonIdempotentMethods = map[string]bool{
+	"createAccount":      true,
+	"sendRawTransaction": true,
+	"sendTransaction":    true,
+	"submitBlock":        true,
+
+	// Albatross
+	"sendBasicTransaction":               true,
+	"sendBasicTransactionWithData":       true,
+	"sendNewStakerTransaction":           true,
+	"sendStakeTransaction":               true,
+	"sendUpdateStakerTransaction":        true,
+	"sendUnstakeTransaction":             true,
+	"sendNewValidatorTransaction":        true,
+	"sendUpdateValidatorTransaction":     true,
+	"sendDeactivateValidatorTransaction": true,
+	"sendReactivateValidatorTransaction": true,
+	"sendRetireValidatorTransaction":     true,
+}
+
+// RetryPolicy describes how failed RPC calls are retried. Only failures that did not
+// result in a JSON-RPC response are retried, a JSON-RPC error returned by the node is final.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a call, including the first attempt.
+	// A value of one or less disables retries.
+	MaxAttempts int
+
+	// InitialBackoff is the time to wait before the first retry.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the time to wait between two attempts. Zero means no cap.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the backoff grows after every attempt.
+	// Values smaller than one are treated as one.
+	Multiplier float64
+
+	// Jitter is the fraction of the backoff, between 0 and 1, that is randomized
+	// to prevent multiple clients from retrying in lockstep.
+	Jitter float64
+
+	// Retryable reports whether a failed attempt can be retried. If nil, IsRetryable is used.
+	Retryable func(err error) bool
+
+	// RetryNonIdempotent allows retrying methods that are not idempotent, such as
+	// sendTransaction, sendRawTransaction and submitBlock. Retrying these methods may
+	// cause them to be executed more than once.
+	RetryNonIdempotent bool
+
+	// OnRetry is called before the client waits for the next attempt.
+	OnRetry func(attempt RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried
+type RetryAttempt struct {
+	Methods []string      // the methods of the call, more than one for a batch call
+	Attempt int           // number of the failed attempt, starting at 1
+	Err     error         // error of the failed attempt
+	Backoff time.Duration // time to wait before the next attempt
+}
+
+// DefaultRetryPolicy returns a RetryPolicy that makes up to four attempts, starting with a
+// backoff of 250 milliseconds that doubles up to a maximum of 5 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    4,
+		InitialBackoff: 250 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+}
+
+// WithRetryPolicy sets the policy that is used to retry failed calls. By default calls are not retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(nc *Client) {
+		nc.retryPolicy = &policy
+	}
+}
+
+// IsRetryable reports whether err is a transient error after which a call can be retried.
+// These are errors of the HTTP transport, timeouts of a single attempt, and HTTP status
+// codes indicating the node is temporarily unavailable.
+func IsRetryable(err error) bool {
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		return true
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	var httpErr *jsonrpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.Code {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	return false
+}
+
+// retry calls fn until it succeeds or the retry policy of the client is exhausted
+func (nc *Client) retry(ctx context.Context, methods []string, fn func() error) error {
+	policy := nc.retryPolicy
+	if policy == nil || policy.MaxAttempts <= 1 || !policy.allows(methods) {
+		return fn()
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !nc.retryable(err) {
+			return err
+		}
+
+		backoff := policy.backoff(attempt)
+		if policy.OnRetry != nil {
+			policy.OnRetry(RetryAttempt{
+				Methods: methods,
+				Attempt: attempt,
+				Err:     err,
+				Backoff: backoff,
+			})
+		}
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable reports whether err is retryable according to the retry policy of the client,
+// or according to IsRetryable if the policy has no classifier
+func (nc *Client) retryable(err error) bool {
+	if nc.retryPolicy != nil && nc.retryPolicy.Retryable != nil {
+		return nc.retryPolicy.Retryable(err)
+	}
+	return IsRetryable(err)
+}
+
+// allows reports whether the policy allows retrying all of the given methods
+func (rp *RetryPolicy) allows(methods []string) bool {
+	return rp.RetryNonIdempotent || idempotent(methods)
+}
+
+// idempotent reports whether all of the given methods can safely be called more than once
+func idempotent(methods []string) bool {
+	for _, method := range methods {
+		if nonIdempotentMethods[method] {
+			return false
+		}
+	}
+	return true
+}
+
+// backoff returns the time to wait after the given failed attempt
+func (rp *RetryPolicy) backoff(attempt int) time.Duration {
+	multiplier := rp.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(rp.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		backoff *= multiplier
+		if rp.MaxBackoff > 0 && backoff > float64(rp.MaxBackoff) {
+			break
+		}
+	}
+	if rp.MaxBackoff > 0 && backoff > float64(rp.MaxBackoff) {
+		backoff = float64(rp.MaxBackoff)
+	}
+
+	if rp.Jitter > 0 {
+		jitter := rp.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		backoff -= backoff * jitter * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
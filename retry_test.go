@@ -0,0 +1,139 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+// flakyServer returns a test node that responds with 503 Service Unavailable to the
+// first failures requests
+func flakyServer(failures int) *nimiqtest.Server {
+	srv := nimiqtest.NewServer()
+	srv.Intercept(func(r *http.Request, methods []string) *nimiqtest.Failure {
+		if srv.Requests() <= failures {
+			return &nimiqtest.Failure{Status: http.StatusServiceUnavailable, Body: "node restarting"}
+		}
+		return nil
+	})
+	srv.Handle("sendRawTransaction", func(params []json.RawMessage) (interface{}, error) {
+		return "0123", nil
+	})
+	return srv
+}
+
+func testRetryPolicy() nimiqrpc.RetryPolicy {
+	policy := nimiqrpc.DefaultRetryPolicy()
+	policy.InitialBackoff = time.Millisecond
+	return policy
+}
+
+func TestRetry(t *testing.T) {
+	srv := flakyServer(2)
+	defer srv.Close()
+
+	var retries []nimiqrpc.RetryAttempt
+	policy := testRetryPolicy()
+	policy.OnRetry = func(attempt nimiqrpc.RetryAttempt) {
+		retries = append(retries, attempt)
+	}
+	nc := srv.Client(nimiqrpc.WithRetryPolicy(policy))
+
+	consensus, err := nc.Consensus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if consensus != nimiqrpc.ConsensusEstablished {
+		t.Errorf("unexpected result %q", consensus)
+	}
+	if srv.Requests() != 3 {
+		t.Errorf("expected 3 requests, got %d", srv.Requests())
+	}
+	if len(retries) != 2 || retries[1].Attempt != 2 || retries[1].Methods[0] != "consensus" {
+		t.Errorf("unexpected retry attempts %+v", retries)
+	}
+}
+
+func TestRetryExhausted(t *testing.T) {
+	srv := flakyServer(10)
+	defer srv.Close()
+
+	nc := srv.Client(nimiqrpc.WithRetryPolicy(testRetryPolicy()))
+	if _, err := nc.Consensus(); err == nil {
+		t.Fatal("expected an error")
+	}
+	if srv.Requests() != 4 {
+		t.Errorf("expected 4 requests, got %d", srv.Requests())
+	}
+}
+
+func TestRetryNonIdempotent(t *testing.T) {
+	srv := flakyServer(1)
+	defer srv.Close()
+
+	nc := srv.Client(nimiqrpc.WithRetryPolicy(testRetryPolicy()))
+	if _, err := nc.SendRawTransaction("00"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if srv.Requests() != 1 {
+		t.Errorf("expected 1 request, got %d", srv.Requests())
+	}
+
+	srv = flakyServer(1)
+	defer srv.Close()
+
+	nc = srv.Client(nimiqrpc.WithRetryPolicy(testRetryPolicy()))
+	if _, err := nc.CallBatch(nimiqrpc.NewRequest("blockNumber"), nimiqrpc.NewRequest("sendRawTransaction", "00")); err == nil {
+		t.Fatal("expected an error")
+	}
+	if srv.Requests() != 1 {
+		t.Errorf("expected 1 request, got %d", srv.Requests())
+	}
+
+	srv = flakyServer(1)
+	defer srv.Close()
+
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	nc = srv.Client(nimiqrpc.WithRetryPolicy(policy))
+	if _, err := nc.SendRawTransaction("00"); err != nil {
+		t.Fatal(err)
+	}
+	if srv.Requests() != 2 {
+		t.Errorf("expected 2 requests, got %d", srv.Requests())
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	nc := srv.Client()
+	srv.Close()
+
+	_, err := nc.BlockNumber()
+	if !errors.Is(err, syscall.ECONNREFUSED) {
+		t.Fatalf("expected connection refused, got %v", err)
+	}
+	if !nimiqrpc.IsRetryable(err) {
+		t.Error("transport error should be retryable")
+	}
+}
@@ -0,0 +1,83 @@
+# Generates transactions.json: python3 transactions.py > transactions.json
+#
+# The transactions are signed with a key derived from a fixed seed, using the Ed25519 reference
+# implementation of RFC 8032 and Blake2b of hashlib, so they are independent of the Go package.
+import hashlib, json, struct
+
+p = 2**255 - 19
+L = 2**252 + 27742317777372353535851937790883648493
+d = -121665 * pow(121666, p - 2, p) % p
+I = pow(2, (p - 1) // 4, p)
+
+def inv(x): return pow(x, p - 2, p)
+def xrecover(y):
+    xx = (y * y - 1) * inv(d * y * y + 1)
+    x = pow(xx, (p + 3) // 8, p)
+    if (x * x - xx) % p != 0: x = (x * I) % p
+    if x % 2 != 0: x = p - x
+    return x
+By = 4 * inv(5) % p
+B = (xrecover(By), By)
+def edwards(P, Q):
+    x1, y1 = P; x2, y2 = Q
+    x3 = (x1 * y2 + x2 * y1) * inv(1 + d * x1 * x2 * y1 * y2)
+    y3 = (y1 * y2 + x1 * x2) * inv(1 - d * x1 * x2 * y1 * y2)
+    return (x3 % p, y3 % p)
+def scalarmult(P, e):
+    Q = (0, 1)
+    while e:
+        if e & 1: Q = edwards(Q, P)
+        P = edwards(P, P); e >>= 1
+    return Q
+def encodepoint(P):
+    x, y = P
+    return (y | ((x & 1) << 255)).to_bytes(32, 'little')
+def H(m): return hashlib.sha512(m).digest()
+def keypair(seed):
+    h = H(seed)
+    a = 2**254 + (int.from_bytes(h[:32], 'little') & ~7 & ((1 << 254) - 1))
+    return a, h[32:], encodepoint(scalarmult(B, a))
+def sign(seed, m):
+    a, prefix, A = keypair(seed)
+    r = int.from_bytes(H(prefix + m), 'little') % L
+    R = encodepoint(scalarmult(B, r))
+    k = int.from_bytes(H(R + A + m), 'little') % L
+    S = (r + k * a) % L
+    return R + S.to_bytes(32, 'little')
+
+def blake2b(b): return hashlib.blake2b(b, digest_size=32).digest()
+def address(pub): return blake2b(pub)[:20]
+
+def content(data, sender, stype, recipient, rtype, value, fee, vsh, net, flags):
+    return (struct.pack('>H', len(data)) + data + sender + bytes([stype]) + recipient + bytes([rtype]) +
+            struct.pack('>QQIBB', value, fee, vsh, net, flags))
+
+seed = blake2b(b'go-nimiq-rpc transaction fixtures')
+_, _, pub = keypair(seed)
+sender = address(pub)
+recipient = address(blake2b(b'go-nimiq-rpc fixture recipient'))
+fixtures = []
+
+# Basic transaction on the main net
+c = content(b'', sender, 0, recipient, 0, 100000, 138, 1000, 42, 0)
+sig = sign(seed, c)
+wire = bytes([0]) + pub + recipient + struct.pack('>QQIB', 100000, 138, 1000, 42) + sig
+fixtures.append(dict(name='basic', network='main', transaction=wire.hex(), hash=blake2b(c).hex()))
+
+# Extended transaction with a message on the test net
+data = b'Hello Nimiq'
+c = content(data, sender, 0, recipient, 0, 5, 0, 7, 1, 0)
+proof = pub + bytes([0]) + sign(seed, c)
+wire = bytes([1]) + c + struct.pack('>H', len(proof)) + proof
+fixtures.append(dict(name='extended with data', network='test', transaction=wire.hex(), hash=blake2b(c).hex()))
+
+# Creation of a vesting contract owned by the sender on the main net
+data = sender + struct.pack('>I', 100)
+zero = content(data, sender, 0, bytes(20), 1, 1000000, 1500, 2000, 42, 1)
+contract = blake2b(zero)[:20]
+c = content(data, sender, 0, contract, 1, 1000000, 1500, 2000, 42, 1)
+proof = pub + bytes([0]) + sign(seed, c)
+wire = bytes([1]) + c + struct.pack('>H', len(proof)) + proof
+fixtures.append(dict(name='contract creation', network='main', transaction=wire.hex(), hash=blake2b(c).hex(), contract=contract.hex()))
+
+print(json.dumps(fixtures, indent=2))
@@ -33,14 +33,38 @@ const (
 
 // Account types on the blockchain
 const (
-	AccountTypeBasic   = 0
-	AccountTypeVesting = 1
-	AccountTypeHTLC    = 2
+	AccountTypeBasic   AccountType = 0
+	AccountTypeVesting AccountType = 1
+	AccountTypeHTLC    AccountType = 2
+)
+
+// Connection states of a miner to its mining pool
+const (
+	PoolConnectionStateConnected  PoolConnectionState = 0
+	PoolConnectionStateConnecting PoolConnectionState = 1
+	PoolConnectionStateClosed     PoolConnectionState = 2
 )
 
 // LogLevel is the level of logging that is enabled on a node
 type LogLevel string
 
+// AccountType is the type of an account on the blockchain
+type AccountType int
+
+// String returns the name of the account type
+func (t AccountType) String() string {
+	switch t {
+	case AccountTypeBasic:
+		return "basic"
+	case AccountTypeVesting:
+		return "vesting"
+	case AccountTypeHTLC:
+		return "htlc"
+	default:
+		return "unknown(" + strconv.Itoa(int(t)) + ")"
+	}
+}
+
 // NIM is the token transacted within Nimiq as a store and transfer of value: it acts as digital cash
 type NIM string
 
@@ -98,10 +122,10 @@ func (l *Luna) ToNIM() NIM {
 
 // Account holds the details on an account
 type Account struct {
-	ID      string `json:"id"`      // hex-encoded address bytes
-	Address string `json:"address"` // user friendly address (NQ-address).
-	Balance Luna   `json:"balance"` // Balance of the account in Luna
-	Type    int    `json:"type"`    // see AccountType const block
+	ID      string      `json:"id"`      // hex-encoded address bytes
+	Address string      `json:"address"` // user friendly address (NQ-address).
+	Balance Luna        `json:"balance"` // Balance of the account in Luna
+	Type    AccountType `json:"type"`    // see AccountType const block
 
 	// Additional fields for AccountTypeVesting
 	Owner              string `json:"owner,omitempty"`              // hex-encoded address of contract owner
@@ -148,8 +172,8 @@ type Block struct {
 	// depending on request parameters.
 	// Depending on the result, the TransactionHashes or TransactionObjects fields are set.
 	Transactions       json.RawMessage `json:"transactions"`
-	TransactionHashes  []string        // hashes of the transactions in the block
-	TransactionObjects []Transaction   // detailed transaction objects
+	TransactionHashes  []string        `json:"-"` // hashes of the transactions in the block
+	TransactionObjects []Transaction   `json:"-"` // detailed transaction objects
 }
 
 // BlockTemplate contains details on a block template
@@ -207,6 +231,13 @@ type Mempool struct {
 	Bucket5000 int `json:"5000,omitempty"`
 }
 
+// MempoolContent holds the transactions in the mempool. Depending on the request parameters,
+// the TransactionHashes or TransactionObjects field is set.
+type MempoolContent struct {
+	TransactionHashes  []string      // hashes of the transactions in the mempool
+	TransactionObjects []Transaction // detailed transaction objects
+}
+
 // Peer holds the details of a peer
 type Peer struct {
 	ID              string `json:"id,omitempty"`
@@ -221,6 +252,23 @@ type Peer struct {
 	TX              int    `json:"tx,omitempty"`
 }
 
+// PoolConnectionState is the state of the connection of a miner to its mining pool
+type PoolConnectionState int
+
+// String returns the name of the connection state
+func (s PoolConnectionState) String() string {
+	switch s {
+	case PoolConnectionStateConnected:
+		return "connected"
+	case PoolConnectionStateConnecting:
+		return "connecting"
+	case PoolConnectionStateClosed:
+		return "closed"
+	default:
+		return "unknown(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
 // Transaction holds the details on a transaction
 type Transaction struct {
 	Hash             string `json:"hash"`                       // hash of transaction
@@ -241,6 +289,13 @@ type Transaction struct {
 	Flags int    `json:"flags"` // bit-encoded transaction flags
 }
 
+// RawTransactionInfo holds the details on a deserialized raw transaction
+type RawTransactionInfo struct {
+	Transaction
+	Valid     bool `json:"valid"`     // whether the transaction is valid
+	InMempool bool `json:"inMempool"` // whether the transaction is in the mempool
+}
+
 // TransactionReceipt holds the details on a transaction receipt.
 type TransactionReceipt struct {
 	TransactionHash  string `json:"transactionHash"`     // hash of transaction
@@ -253,14 +308,15 @@ type TransactionReceipt struct {
 
 // OutgoingTransaction holds the details on a transaction that is not yet sent.
 type OutgoingTransaction struct {
-	From     string `json:"from"`               // address of sending account
-	FromType int    `json:"fromType,omitempty"` // AccountType of sending address (default AccountTypeBasic)
-	To       string `json:"to"`                 // address of recipient account
-	ToType   int    `json:"toType,omitempty"`   // AccountType of recipient address (default AccountTypeBasic)
+	From     string      `json:"from"`               // address of sending account
+	FromType AccountType `json:"fromType,omitempty"` // type of sending account (default AccountTypeBasic)
+	To       string      `json:"to"`                 // address of recipient account
+	ToType   AccountType `json:"toType,omitempty"`   // type of recipient account (default AccountTypeBasic)
 
 	Value Luna   `json:"value"`
 	Fee   Luna   `json:"fee"`
-	Data  string `json:"data,omitempty"` // hex-encoded contract parameters or a message
+	Data  string `json:"data,omitempty"`  // hex-encoded contract parameters or a message
+	Flags int    `json:"flags,omitempty"` // bit-encoded transaction flags (default none)
 }
 
 // SyncStatus holds information about the sync status.
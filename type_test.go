@@ -67,3 +67,18 @@ func TestMaxLuna(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestPoolConnectionStateString(t *testing.T) {
+	if PoolConnectionStateConnected.String() != "connected" {
+		t.Fail()
+	}
+	if PoolConnectionStateConnecting.String() != "connecting" {
+		t.Fail()
+	}
+	if PoolConnectionStateClosed.String() != "closed" {
+		t.Fail()
+	}
+	if PoolConnectionState(7).String() != "unknown(7)" {
+		t.Fail()
+	}
+}
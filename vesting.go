@@ -0,0 +1,202 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrNotVestingAccount is returned when vesting details are requested for an account that is not a vesting contract
+var ErrNotVestingAccount = errors.New("not a vesting account")
+
+// maxVestingSteps bounds the number of steps of a release schedule, to protect against
+// contracts that release tiny amounts in a huge number of steps
+const maxVestingSteps = 100000
+
+// Vesting holds the parameters of a vesting contract. Starting at the Start block, StepAmount is
+// released every StepBlocks blocks until TotalAmount is released. The owner can transfer the
+// released funds at any time.
+type Vesting struct {
+	Owner       Address
+	Start       uint32 // height of the block at which the vesting starts
+	StepBlocks  uint32 // number of blocks between releases
+	StepAmount  Luna   // amount released at every step
+	TotalAmount Luna   // total amount locked in the contract
+}
+
+// VestingFromAccount returns the vesting parameters of a vesting account
+func VestingFromAccount(acc Account) (Vesting, error) {
+	if acc.Type != AccountTypeVesting {
+		return Vesting{}, fmt.Errorf("%w: account %s has type %s", ErrNotVestingAccount, acc.Address, acc.Type)
+	}
+
+	address, err := acc.ParsedOwner()
+	if err != nil {
+		return Vesting{}, err
+	}
+
+	return Vesting{
+		Owner:       address,
+		Start:       uint32(acc.VestingStart),
+		StepBlocks:  uint32(acc.VestingStepBlocks),
+		StepAmount:  Luna(acc.VestingStepAmount),
+		TotalAmount: Luna(acc.VestingTotalAmount),
+	}, nil
+}
+
+// Data returns the data of the transaction that creates the vesting contract with the given value.
+// A zero TotalAmount means the value of the transaction and a zero StepAmount means the total amount,
+// which releases all funds at once. The shortest encoding for the parameters is used.
+func (v Vesting) Data(value Luna) []byte {
+	v = v.withDefaults(value)
+
+	data := make([]byte, 0, 44)
+	data = append(data, v.Owner[:]...)
+
+	switch {
+	case v.Start == 0 && v.StepAmount == value && v.TotalAmount == value:
+		data = appendUint32(data, v.StepBlocks)
+	case v.TotalAmount == value:
+		data = appendUint32(data, v.Start)
+		data = appendUint32(data, v.StepBlocks)
+		data = appendUint64(data, uint64(v.StepAmount))
+	default:
+		data = appendUint32(data, v.Start)
+		data = appendUint32(data, v.StepBlocks)
+		data = appendUint64(data, uint64(v.StepAmount))
+		data = appendUint64(data, uint64(v.TotalAmount))
+	}
+	return data
+}
+
+// OutgoingTransaction returns the transaction that creates the vesting contract, funded with value by from.
+// See TransactionFlagContractCreation for how to send it.
+func (v Vesting) OutgoingTransaction(from Address, value, fee Luna) OutgoingTransaction {
+	return contractCreation(AccountTypeVesting, from, value, fee, v.Data(value))
+}
+
+// withDefaults replaces the zero amounts of v by their defaults for a contract with the given value
+func (v Vesting) withDefaults(value Luna) Vesting {
+	if v.TotalAmount == 0 {
+		v.TotalAmount = value
+	}
+	if v.StepAmount == 0 {
+		v.StepAmount = v.TotalAmount
+	}
+	return v
+}
+
+// Locked returns the amount that is still locked at the given height
+func (v Vesting) Locked(height int) Luna {
+	if v.StepBlocks == 0 || v.StepAmount <= 0 {
+		return 0
+	}
+	if height < int(v.Start) {
+		return v.TotalAmount
+	}
+
+	steps := Luna((height - int(v.Start)) / int(v.StepBlocks))
+	if steps >= (v.TotalAmount+v.StepAmount-1)/v.StepAmount {
+		return 0
+	}
+	return v.TotalAmount - steps*v.StepAmount
+}
+
+// VestingRelease is a step of the release schedule of a vesting contract
+type VestingRelease struct {
+	Height   int  // height of the block at which the amount is released
+	Amount   Luna // amount released at this step
+	Released Luna // total amount released up to and including this step
+}
+
+// Schedule returns the release schedule of the vesting contract. It returns nil if the
+// funds are not locked at all.
+func (v Vesting) Schedule() ([]VestingRelease, error) {
+	if v.StepBlocks == 0 || v.StepAmount <= 0 || v.TotalAmount <= 0 {
+		return nil, nil
+	}
+
+	steps := (v.TotalAmount + v.StepAmount - 1) / v.StepAmount
+	if steps > maxVestingSteps {
+		return nil, fmt.Errorf("vesting schedule has %d steps, more than %d", steps, maxVestingSteps)
+	}
+
+	schedule := make([]VestingRelease, 0, steps)
+	released := Luna(0)
+	for step := 1; released < v.TotalAmount; step++ {
+		amount := v.StepAmount
+		if remaining := v.TotalAmount - released; amount > remaining {
+			amount = remaining
+		}
+		released += amount
+
+		schedule = append(schedule, VestingRelease{
+			Height:   int(v.Start) + step*int(v.StepBlocks),
+			Amount:   amount,
+			Released: released,
+		})
+	}
+	return schedule, nil
+}
+
+// VestingStatus holds the state of a vesting account at a certain height
+type VestingStatus struct {
+	Height     int              // height at which the status is calculated
+	Releasable Luna             // balance the owner can transfer
+	Locked     Luna             // balance that is still locked
+	Schedule   []VestingRelease // full release schedule of the contract
+}
+
+// CalculateVesting returns the releasable and locked balance of a vesting account at the given height,
+// together with the release schedule of the contract
+func CalculateVesting(acc Account, height int) (*VestingStatus, error) {
+	vesting, err := VestingFromAccount(acc)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := vesting.Schedule()
+	if err != nil {
+		return nil, err
+	}
+
+	// Released funds that were already transferred are no longer part of the balance
+	locked := vesting.Locked(height)
+	if locked > acc.Balance {
+		locked = acc.Balance
+	}
+
+	return &VestingStatus{
+		Height:     height,
+		Releasable: acc.Balance - locked,
+		Locked:     locked,
+		Schedule:   schedule,
+	}, nil
+}
+
+// appendUint32 appends v in big endian byte order
+func appendUint32(b []byte, v uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+// appendUint64 appends v in big endian byte order
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
@@ -0,0 +1,149 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestVestingData(t *testing.T) {
+	owner := MustParseAddress("NQ49 006H L9RL 8575 NS3M GA7R RADN QF8D TSPP")
+
+	for _, test := range []struct {
+		vesting  Vesting
+		value    Luna
+		expected string
+	}{
+		{Vesting{Owner: owner, StepBlocks: 100}, 5000, owner.Hex() + "00000064"},
+		{Vesting{Owner: owner, Start: 10, StepBlocks: 100, StepAmount: 1000}, 5000, owner.Hex() + "0000000a" + "00000064" + "00000000000003e8"},
+		{Vesting{Owner: owner, StepBlocks: 100, StepAmount: 1000, TotalAmount: 4000}, 5000, owner.Hex() + "00000000" + "00000064" + "00000000000003e8" + "0000000000000fa0"},
+	} {
+		if data := hex.EncodeToString(test.vesting.Data(test.value)); data != test.expected {
+			t.Errorf("expected data %s for %+v, got %s", test.expected, test.vesting, data)
+		}
+	}
+
+	trn := Vesting{Owner: owner, StepBlocks: 100}.OutgoingTransaction(owner, 5000, 0)
+	raw, err := NewRawTransaction(trn, 1, NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.RecipientType != AccountTypeVesting || raw.Recipient != raw.ContractCreationAddress() || len(raw.Data) != 24 {
+		t.Errorf("unexpected transaction %+v", raw)
+	}
+	if _, err := NewClient("http://127.0.0.1:1").SendTransaction(trn); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("SendTransaction: expected ErrInvalidTransaction, got %v", err)
+	}
+}
+
+func TestCalculateVesting(t *testing.T) {
+	acc := Account{
+		Address:            "NQ07 0000 0000 0000 0000 0000 0000 0000 0000",
+		Balance:            10000,
+		Type:               AccountTypeVesting,
+		Owner:              "000d1a2734414e5b6875828f9ca9b6c3d0ddeaf7",
+		VestingStart:       100,
+		VestingStepBlocks:  50,
+		VestingStepAmount:  3000,
+		VestingTotalAmount: 10000,
+	}
+
+	for _, test := range []struct {
+		height, releasable, locked int
+	}{
+		{0, 0, 10000},
+		{149, 0, 10000},
+		{150, 3000, 7000},
+		{250, 9000, 1000},
+		{300, 10000, 0},
+	} {
+		status, err := CalculateVesting(acc, test.height)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if status.Releasable != Luna(test.releasable) || status.Locked != Luna(test.locked) {
+			t.Errorf("height %d: expected %d releasable and %d locked, got %d and %d",
+				test.height, test.releasable, test.locked, status.Releasable, status.Locked)
+		}
+	}
+
+	status, err := CalculateVesting(acc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []VestingRelease{
+		{Height: 150, Amount: 3000, Released: 3000},
+		{Height: 200, Amount: 3000, Released: 6000},
+		{Height: 250, Amount: 3000, Released: 9000},
+		{Height: 300, Amount: 1000, Released: 10000},
+	}
+	if !reflect.DeepEqual(status.Schedule, expected) {
+		t.Errorf("unexpected schedule %+v", status.Schedule)
+	}
+
+	// Transferred funds reduce the releasable balance first
+	acc.Balance = 5000
+	if status, _ := CalculateVesting(acc, 200); status.Releasable != 1000 || status.Locked != 4000 {
+		t.Errorf("unexpected status %+v", status)
+	}
+
+	acc.Type = AccountTypeBasic
+	if _, err := CalculateVesting(acc, 200); !errors.Is(err, ErrNotVestingAccount) {
+		t.Errorf("expected ErrNotVestingAccount, got %v", err)
+	}
+}
+
+func TestVestingWithdrawal(t *testing.T) {
+	owner := testKeyPair(t)
+	creation, err := NewRawTransaction(Vesting{Owner: owner.Address(), StepBlocks: 100}.OutgoingTransaction(owner.Address(), 5000, 0), 1, NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contract := creation.ContractCreationAddress()
+
+	// The owner signs the withdrawal from the contract
+	raw, err := NewRawTransaction(OutgoingTransaction{
+		From:     contract.String(),
+		FromType: AccountTypeVesting,
+		To:       owner.Address().String(),
+		Value:    1000,
+		Fee:      0,
+	}, 200, NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := raw.Sign(owner); err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	signed, err := raw.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeRawTransaction(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Sender != contract || decoded.SenderType != AccountTypeVesting || decoded.Verify() != nil {
+		t.Errorf("unexpected withdrawal %+v", decoded)
+	}
+
+	raw.SenderType = AccountTypeHTLC
+	if err := raw.Sign(owner); !errors.Is(err, ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction for HTLC sender, got %v", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var (
+	// ErrTransactionExpired is returned when a transaction was not mined within its validity window
+	ErrTransactionExpired = errors.New("transaction expired")
+
+	// ErrTransactionDropped is returned when a transaction was removed from the mempool before it was mined
+	ErrTransactionDropped = errors.New("transaction dropped from the mempool")
+)
+
+// transactionValidityWindow is the number of blocks after its validity start height
+// in which a transaction can be mined
+const transactionValidityWindow = 120
+
+// defaultPollInterval is the interval at which the node is polled if no poll interval is configured
+const defaultPollInterval = 5 * time.Second
+
+// WaitForConfirmations waits until the transaction with the given hash is mined and confirmed by n blocks,
+// and returns its receipt. The block that includes the transaction counts as the first confirmation.
+//
+// The node is polled at the poll interval of the client, and the receipt is only requested when a new
+// block was mined. Errors that the retry policy of the client classifies as retryable do not end the
+// wait, polling continues until ctx is done. If the transaction is no longer known to the node before
+// it is mined, ErrTransactionDropped is returned. If the transaction is orphaned by a chain
+// reorganisation, waiting continues until it is mined again.
+//
+// The validity start height of the transaction is not known from its hash, so WaitForConfirmations
+// never returns ErrTransactionExpired. Nodes remove expired transactions from their mempool, which ends
+// the wait with ErrTransactionDropped. Use WaitForConfirmationsFrom or WaitForRawTransaction to detect
+// the expiry as soon as the validity window ends.
+func (nc *Client) WaitForConfirmations(ctx context.Context, txHash string, n int) (*TransactionReceipt, error) {
+	return nc.waitForConfirmations(ctx, txHash, n, 0)
+}
+
+// WaitForConfirmationsFrom is like WaitForConfirmations, but returns ErrTransactionExpired if the
+// transaction is not mined within the validity window after the given validity start height.
+func (nc *Client) WaitForConfirmationsFrom(ctx context.Context, txHash string, n int, validityStartHeight int) (*TransactionReceipt, error) {
+	if validityStartHeight <= 0 {
+		return nil, fmt.Errorf("invalid validity start height %d", validityStartHeight)
+	}
+	return nc.waitForConfirmations(ctx, txHash, n, validityStartHeight)
+}
+
+// WaitForRawTransaction is like WaitForConfirmationsFrom for the hex-encoded transaction, like a
+// transaction passed to SendRawTransaction. The hash and the validity start height are decoded from
+// the transaction.
+func (nc *Client) WaitForRawTransaction(ctx context.Context, transactionHex string, n int) (*TransactionReceipt, error) {
+	t, err := DecodeRawTransaction(transactionHex)
+	if err != nil {
+		return nil, err
+	}
+	return nc.WaitForConfirmationsFrom(ctx, t.Hash(), n, int(t.ValidityStartHeight))
+}
+
+// waitForConfirmations polls the node until the transaction has n confirmations. If startHeight is 0,
+// the validity start height is unknown and the transaction is never considered expired.
+func (nc *Client) waitForConfirmations(ctx context.Context, txHash string, n, startHeight int) (*TransactionReceipt, error) {
+	interval := nc.pollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastHeight := 0
+	for {
+		head, err := nc.BlockNumberContext(ctx)
+		if err == nil {
+			if head != lastHeight {
+				var receipt *TransactionReceipt
+				receipt, err = nc.waitStep(ctx, txHash, n, startHeight, head)
+				if receipt != nil {
+					return receipt, nil
+				}
+				if err == nil {
+					lastHeight = head
+				}
+			}
+		}
+		if err != nil && !nc.keepWaiting(ctx, err) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// keepWaiting reports whether polling continues after err, which is the case for retryable errors
+// of the node while ctx is not done
+func (nc *Client) keepWaiting(ctx context.Context, err error) bool {
+	if ctx.Err() != nil || errors.Is(err, ErrTransactionExpired) || errors.Is(err, ErrTransactionDropped) {
+		return false
+	}
+	return nc.retryable(err)
+}
+
+// waitStep checks the state of the transaction at the given head. It returns the receipt when the transaction
+// has n confirmations, an error when it can no longer be mined, or neither when waiting continues.
+func (nc *Client) waitStep(ctx context.Context, txHash string, n, startHeight, head int) (*TransactionReceipt, error) {
+	receipt, err := nc.GetTransactionReceiptContext(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if receipt != nil {
+		if receipt.Confirmations >= n {
+			return receipt, nil
+		}
+		return nil, nil
+	}
+
+	trn, err := nc.GetTransactionByHashContext(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	// The transaction may have been mined since the receipt was requested
+	if trn != nil && trn.BlockNumber > 0 {
+		return nil, nil
+	}
+
+	if startHeight > 0 && head+1 >= startHeight+transactionValidityWindow {
+		return nil, fmt.Errorf("%w: %s was not mined before block %d", ErrTransactionExpired, txHash, startHeight+transactionValidityWindow)
+	}
+	if trn == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTransactionDropped, txHash)
+	}
+
+	return nil, nil
+}
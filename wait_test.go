@@ -0,0 +1,239 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redmaner/go-nimiq-rpc"
+	"github.com/redmaner/go-nimiq-rpc/nimiqtest"
+)
+
+func TestWaitForConfirmations(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10 * time.Millisecond))
+
+	alice := srv.NewAccount(100000)
+	hash, err := client.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    alice.Address,
+		Value: 100,
+		Fee:   138,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	done := make(chan *nimiqrpc.TransactionReceipt)
+	go func() {
+		receipt, err := client.WaitForConfirmations(ctx, hash, 3)
+		if err != nil {
+			t.Error(err)
+		}
+		done <- receipt
+	}()
+
+	mined := srv.MineBlock()
+	srv.MineBlock()
+	srv.MineBlock()
+
+	receipt := <-done
+	if receipt == nil || receipt.BlockHash != mined.Hash || receipt.Confirmations < 3 {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
+}
+
+func TestWaitForConfirmationsDropped(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10 * time.Millisecond))
+
+	_, err := client.WaitForConfirmations(context.Background(), "0000000000000000000000000000000000000000000000000000000000000000", 1)
+	if !errors.Is(err, nimiqrpc.ErrTransactionDropped) {
+		t.Errorf("expected ErrTransactionDropped, got %v", err)
+	}
+}
+
+func TestWaitForConfirmationsUnknownStartHeight(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10 * time.Millisecond))
+
+	alice := srv.NewAccount(100000)
+	hash, err := client.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    alice.Address,
+		Value: 100,
+		Fee:   138,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The chain advances far beyond any validity window while the transaction stays in the
+	// mempool, until the node drops it
+	heights := []int{10, 200, 400, 401}
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		height := heights[0]
+		if len(heights) > 1 {
+			heights = heights[1:]
+		}
+		return height, nil
+	})
+	srv.Handle("getTransactionByHash", func(params []json.RawMessage) (interface{}, error) {
+		if len(heights) > 1 {
+			return nimiqrpc.Transaction{Hash: hash, FromAddress: alice.Address, ToAddress: alice.Address, Value: 100, Fee: 138}, nil
+		}
+		return nil, nil
+	})
+
+	_, err = client.WaitForConfirmations(context.Background(), hash, 1)
+	if !errors.Is(err, nimiqrpc.ErrTransactionDropped) {
+		t.Errorf("expected ErrTransactionDropped, got %v", err)
+	}
+	if len(heights) != 1 {
+		t.Errorf("expected waiting to continue until the transaction is dropped, %d heights left", len(heights))
+	}
+}
+
+func TestWaitForConfirmationsFrom(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10 * time.Millisecond))
+
+	alice := srv.NewAccount(100000)
+	hash, err := client.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    alice.Address,
+		Value: 100,
+		Fee:   138,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The validity window of the transaction started before waiting started
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		return 129, nil
+	})
+
+	_, err = client.WaitForConfirmationsFrom(context.Background(), hash, 1, 10)
+	if !errors.Is(err, nimiqrpc.ErrTransactionExpired) {
+		t.Errorf("expected ErrTransactionExpired, got %v", err)
+	}
+}
+
+func TestWaitForConfirmationsTransientError(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10*time.Millisecond), nimiqrpc.WithRetryPolicy(nimiqrpc.RetryPolicy{
+		MaxAttempts: 1,
+		Retryable: func(err error) bool {
+			return true
+		},
+	}))
+
+	alice := srv.NewAccount(100000)
+	hash, err := srv.SendTransaction(nimiqrpc.OutgoingTransaction{
+		From:  alice.Address,
+		To:    alice.Address,
+		Value: 100,
+		Fee:   138,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mined := srv.MineBlock()
+
+	// The node fails the first calls for the receipt
+	failures := 3
+	srv.Handle("getTransactionReceipt", func(params []json.RawMessage) (interface{}, error) {
+		if failures > 0 {
+			failures--
+			return nil, &nimiqrpc.RPCError{Code: nimiqtest.CodeInternalError, Message: "unavailable"}
+		}
+		return nimiqrpc.TransactionReceipt{TransactionHash: hash, BlockHash: mined.Hash, BlockNumber: mined.Number, Confirmations: 1}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	receipt, err := client.WaitForConfirmations(ctx, hash, 1)
+	if err != nil || receipt == nil || receipt.BlockHash != mined.Hash {
+		t.Errorf("expected receipt after transient errors, got %+v, %v", receipt, err)
+	}
+	if failures != 0 {
+		t.Errorf("expected all failures to be consumed, %d left", failures)
+	}
+}
+
+func TestWaitForRawTransaction(t *testing.T) {
+	srv := nimiqtest.NewServer()
+	defer srv.Close()
+	client := srv.Client(nimiqrpc.WithPollInterval(10 * time.Millisecond))
+
+	kp, err := nimiqrpc.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.AddAccount(nimiqrpc.Account{Address: kp.Address().String(), Balance: 100000})
+
+	raw, err := nimiqrpc.NewRawTransaction(nimiqrpc.OutgoingTransaction{
+		From:  kp.Address().String(),
+		To:    kp.Address().String(),
+		Value: 100,
+		Fee:   138,
+	}, 10, nimiqrpc.NetworkIDTest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := raw.Sign(kp); err != nil {
+		t.Fatal(err)
+	}
+	transactionHex, err := raw.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := client.SendRawTransaction(transactionHex)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The validity start height is decoded from the transaction, which was signed before waiting started
+	srv.Handle("blockNumber", func(params []json.RawMessage) (interface{}, error) {
+		return 129, nil
+	})
+
+	_, err = client.WaitForRawTransaction(context.Background(), transactionHex, 1)
+	if !errors.Is(err, nimiqrpc.ErrTransactionExpired) {
+		t.Fatalf("expected ErrTransactionExpired, got %v", err)
+	}
+	if !strings.Contains(err.Error(), hash) {
+		t.Errorf("expected error for transaction %s, got %v", hash, err)
+	}
+
+	if _, err := client.WaitForRawTransaction(context.Background(), "00", 1); !errors.Is(err, nimiqrpc.ErrInvalidTransaction) {
+		t.Errorf("expected ErrInvalidTransaction, got %v", err)
+	}
+}
@@ -0,0 +1,728 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/ybbus/jsonrpc"
+)
+
+var (
+	// ErrClosed is returned by calls on a WebSocketClient that is closed
+	ErrClosed = errors.New("client closed")
+
+	// ErrNotConnected is returned by calls on a WebSocketClient that lost its connection
+	// to the node before a response was received
+	ErrNotConnected = errors.New("not connected")
+
+	// ErrSubscriptionOverflow ends a Subscription whose notifications are not received
+	// fast enough to fit in its buffer
+	ErrSubscriptionOverflow = errors.New("subscription overflow")
+)
+
+const (
+	// reconnectMinBackoff is the time to wait before the first attempt to reconnect
+	reconnectMinBackoff = 100 * time.Millisecond
+
+	// reconnectMaxBackoff is the maximum time to wait between two attempts to reconnect
+	reconnectMaxBackoff = 10 * time.Second
+
+	// notificationBuffer is the number of notifications buffered per subscription
+	notificationBuffer = 16
+
+	// unsubscribeMethod is the method that cancels a subscription at the node
+	unsubscribeMethod = "unsubscribe"
+)
+
+var _ NimiqAPI = (*WebSocketClient)(nil)
+
+// WebSocketClient is a Nimiq RPC client that sends its calls over a single WebSocket connection.
+// Calls are multiplexed over the connection, so they can be made concurrently. Besides calls,
+// the connection delivers the notifications of subscriptions, see Subscribe.
+//
+// When the connection is lost, the client reconnects in the background and renews all
+// active subscriptions. Calls that were waiting for a response fail with ErrNotConnected,
+// which is retryable, and calls made while disconnected wait until the client is reconnected.
+//
+// WebSocketClient embeds a Client, so it provides the same methods as Client.
+type WebSocketClient struct {
+	*Client
+
+	url    string
+	dialer websocket.Dialer
+
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	connected chan struct{} // closed while a connection is established
+	nextID    int
+	pending   map[int]chan *jsonrpc.RPCResponse
+	hooks     map[int]func(*jsonrpc.RPCResponse) // run on the connection before a response is delivered
+	subs      map[string]*Subscription           // active subscriptions by subscription ID
+
+	writeMu sync.Mutex
+	closed  chan struct{}
+	done    chan struct{}
+}
+
+// DialWebSocket connects to the node at the given WebSocket URL, for example "ws://localhost:8648/ws",
+// and returns a WebSocketClient. The options are applied to the embedded Client. Options that
+// configure HTTP, like WithHTTPClient, do not apply to the WebSocket connection, but headers
+// are sent with the opening handshake.
+//
+// The client reconnects in the background until Close is called.
+func DialWebSocket(ctx context.Context, url string, opts ...Option) (*WebSocketClient, error) {
+	wc := &WebSocketClient{
+		Client:    NewClient(url, opts...),
+		url:       url,
+		dialer:    *websocket.DefaultDialer,
+		connected: make(chan struct{}),
+		pending:   make(map[int]chan *jsonrpc.RPCResponse),
+		hooks:     make(map[int]func(*jsonrpc.RPCResponse)),
+		subs:      make(map[string]*Subscription),
+		closed:    make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	wc.Client.backend = wc
+
+	conn, err := wc.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	wc.setConn(conn)
+
+	go wc.run(conn)
+
+	return wc, nil
+}
+
+// Close closes the connection and ends all subscriptions
+func (wc *WebSocketClient) Close() error {
+	select {
+	case <-wc.closed:
+		return nil
+	default:
+		close(wc.closed)
+	}
+
+	wc.mu.Lock()
+	conn := wc.conn
+	wc.mu.Unlock()
+
+	var err error
+	if conn != nil {
+		wc.writeMu.Lock()
+		conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+		wc.writeMu.Unlock()
+		err = conn.Close()
+	}
+	<-wc.done
+
+	wc.mu.Lock()
+	for id, sub := range wc.subs {
+		delete(wc.subs, id)
+		sub.end(nil)
+	}
+	wc.mu.Unlock()
+
+	return err
+}
+
+// dial opens a new connection to the node
+func (wc *WebSocketClient) dial(ctx context.Context) (*websocket.Conn, error) {
+	header := make(http.Header)
+	for key, value := range wc.headers {
+		header.Set(key, value)
+	}
+
+	conn, resp, err := wc.dialer.DialContext(ctx, wc.url, header)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				return nil, fmt.Errorf("%w: %v", ErrNotAuthenticated, err)
+			case http.StatusForbidden:
+				return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+			}
+		}
+		return nil, &TransportError{msg: err.Error(), err: err}
+	}
+
+	return conn, nil
+}
+
+// setConn makes conn the current connection. It returns false if the client was closed,
+// in which case conn is closed instead.
+func (wc *WebSocketClient) setConn(conn *websocket.Conn) bool {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	// Close only closes the current connection, so a connection that was established
+	// after Close looked at it must not be used
+	select {
+	case <-wc.closed:
+		conn.Close()
+		return false
+	default:
+	}
+
+	wc.conn = conn
+	close(wc.connected)
+	return true
+}
+
+// run reads from the connection, and reconnects when the connection is lost
+func (wc *WebSocketClient) run(conn *websocket.Conn) {
+	defer close(wc.done)
+
+	for {
+		wc.read(conn)
+
+		// The connection is lost, fail all calls that are waiting for a response
+		wc.mu.Lock()
+		wc.conn = nil
+		wc.connected = make(chan struct{})
+		failed := make(map[chan *jsonrpc.RPCResponse]bool)
+		for id, c := range wc.pending {
+			delete(wc.pending, id)
+			delete(wc.hooks, id)
+			if !failed[c] {
+				failed[c] = true
+				close(c)
+			}
+		}
+		wc.mu.Unlock()
+		conn.Close()
+
+		conn = wc.reconnect()
+		if conn == nil || !wc.setConn(conn) {
+			return
+		}
+
+		go wc.resubscribe()
+	}
+}
+
+// reconnect dials the node with an exponential backoff until it succeeds, or the client is closed
+func (wc *WebSocketClient) reconnect() *websocket.Conn {
+	backoff := reconnectMinBackoff
+	for {
+		select {
+		case <-wc.closed:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			select {
+			case <-wc.closed:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+		conn, err := wc.dial(ctx)
+		cancel()
+		if err == nil {
+			return conn
+		}
+
+		backoff *= 2
+		if backoff > reconnectMaxBackoff {
+			backoff = reconnectMaxBackoff
+		}
+	}
+}
+
+// resubscribe renews all active subscriptions after a reconnect. Subscriptions that cannot be
+// renewed because the node rejects them are ended with the error of the node.
+func (wc *WebSocketClient) resubscribe() {
+	wc.mu.Lock()
+	subs := make([]*Subscription, 0, len(wc.subs))
+	seen := make(map[*Subscription]bool, len(wc.subs))
+	for key, sub := range wc.subs {
+		delete(wc.subs, key)
+		if !seen[sub] {
+			seen[sub] = true
+			subs = append(subs, sub)
+		}
+	}
+	wc.mu.Unlock()
+
+	for _, sub := range subs {
+		err := wc.subscribe(context.Background(), sub)
+		var rpcErr *RPCError
+		switch {
+		case err == nil:
+		case errors.As(err, &rpcErr):
+			sub.end(err)
+		default:
+			// The connection was lost again, the subscription is renewed after the next reconnect
+			wc.mu.Lock()
+			select {
+			case <-sub.ended:
+			default:
+				wc.subs[sub.key()] = sub
+			}
+			wc.mu.Unlock()
+		}
+	}
+}
+
+// read dispatches the messages received on conn until the connection fails
+func (wc *WebSocketClient) read(conn *websocket.Conn) {
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		data = bytes.TrimSpace(data)
+		if len(data) > 0 && data[0] == '[' {
+			var rpcResps []*jsonrpc.RPCResponse
+			if json.Unmarshal(data, &rpcResps) == nil {
+				for _, rpcResp := range rpcResps {
+					wc.dispatch(rpcResp)
+				}
+			}
+			continue
+		}
+
+		var msg struct {
+			Method string `json:"method"`
+			Params struct {
+				Subscription json.RawMessage `json:"subscription"`
+				Result       json.RawMessage `json:"result"`
+			} `json:"params"`
+		}
+		if json.Unmarshal(data, &msg) != nil {
+			continue
+		}
+		if msg.Method != "" {
+			wc.notify(msg.Method, subscriptionID(msg.Params.Subscription), msg.Params.Result)
+			continue
+		}
+
+		var rpcResp jsonrpc.RPCResponse
+		if json.Unmarshal(data, &rpcResp) == nil {
+			wc.dispatch(&rpcResp)
+		}
+	}
+}
+
+// dispatch delivers a response to the call that is waiting for it
+func (wc *WebSocketClient) dispatch(rpcResp *jsonrpc.RPCResponse) {
+	wc.mu.Lock()
+	c, ok := wc.pending[rpcResp.ID]
+	hook := wc.hooks[rpcResp.ID]
+	delete(wc.pending, rpcResp.ID)
+	delete(wc.hooks, rpcResp.ID)
+	wc.mu.Unlock()
+
+	if hook != nil {
+		hook(rpcResp)
+	}
+	if ok {
+		c <- rpcResp
+	}
+}
+
+// notify delivers a notification to its subscription. It does not block the connection: if the
+// buffer of the subscription is full, the subscription is ended with ErrSubscriptionOverflow and
+// cancelled at the node.
+func (wc *WebSocketClient) notify(method, id string, result json.RawMessage) {
+	wc.mu.Lock()
+	sub, ok := wc.subs[method+"\x00"+id]
+	if !ok {
+		// Nodes do not agree on the method of notifications, so fall back to the ID only
+		for _, s := range wc.subs {
+			if s.id == id {
+				sub, ok = s, true
+				break
+			}
+		}
+	}
+	wc.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	sub.mu.Lock()
+	select {
+	case <-sub.ended:
+		sub.mu.Unlock()
+		return
+	default:
+	}
+
+	select {
+	case sub.c <- result:
+		sub.mu.Unlock()
+		return
+	default:
+	}
+	sub.mu.Unlock()
+
+	// Cancelling the subscription waits for the response of the node, which is read on this goroutine
+	go sub.cancel(context.Background(), ErrSubscriptionOverflow)
+}
+
+// send writes a message with n requests to the connection and returns the channel on which the
+// responses are delivered, and the IDs of the requests. It waits until the client is connected.
+// If on is not nil, the message is only written to that connection, without waiting.
+func (wc *WebSocketClient) send(ctx context.Context, on *websocket.Conn, n int, build func(nextID func() int) interface{}) (chan *jsonrpc.RPCResponse, []int, error) {
+	for {
+		wc.mu.Lock()
+		conn, connected := wc.conn, wc.connected
+		wc.mu.Unlock()
+
+		if on != nil && conn != on {
+			return nil, nil, &TransportError{msg: ErrNotConnected.Error(), err: ErrNotConnected}
+		}
+		if conn != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-wc.closed:
+			return nil, nil, ErrClosed
+		case <-connected:
+		}
+	}
+
+	wc.mu.Lock()
+	conn := wc.conn
+	c := make(chan *jsonrpc.RPCResponse, n)
+	var ids []int
+	msg := build(func() int {
+		wc.nextID++
+		ids = append(ids, wc.nextID)
+		wc.pending[wc.nextID] = c
+		return wc.nextID
+	})
+	wc.mu.Unlock()
+
+	if conn == nil || (on != nil && conn != on) {
+		wc.forget(ids)
+		return nil, nil, &TransportError{msg: ErrNotConnected.Error(), err: ErrNotConnected}
+	}
+
+	wc.writeMu.Lock()
+	deadline, _ := ctx.Deadline()
+	conn.SetWriteDeadline(deadline)
+	err := conn.WriteJSON(msg)
+	wc.writeMu.Unlock()
+	if err != nil {
+		wc.forget(ids)
+		return nil, nil, &TransportError{msg: err.Error(), err: err}
+	}
+
+	return c, ids, nil
+}
+
+// forget removes the given calls from the calls waiting for a response
+func (wc *WebSocketClient) forget(ids []int) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	for _, id := range ids {
+		delete(wc.pending, id)
+		delete(wc.hooks, id)
+	}
+}
+
+// abandon removes the given calls from the calls waiting for a response. Unlike forget, the hooks
+// of the calls still run if their responses arrive.
+func (wc *WebSocketClient) abandon(ids []int) {
+	wc.mu.Lock()
+	defer wc.mu.Unlock()
+
+	for _, id := range ids {
+		delete(wc.pending, id)
+	}
+}
+
+// receive waits for a response on c
+func (wc *WebSocketClient) receive(ctx context.Context, c chan *jsonrpc.RPCResponse, ids []int) (*jsonrpc.RPCResponse, error) {
+	select {
+	case <-ctx.Done():
+		wc.abandon(ids)
+		return nil, ctx.Err()
+	case <-wc.closed:
+		return nil, ErrClosed
+	case rpcResp, ok := <-c:
+		if !ok {
+			return nil, &TransportError{msg: ErrNotConnected.Error(), err: ErrNotConnected}
+		}
+		return rpcResp, nil
+	}
+}
+
+// call implements backend
+func (wc *WebSocketClient) call(ctx context.Context, method string, params interface{}) (*jsonrpc.RPCResponse, error) {
+	return wc.callWithHook(ctx, nil, method, params, nil)
+}
+
+// callWithHook does a single attempt of an RPC call. If on is not nil, the call is only made on that
+// connection. If hook is not nil, it is run with the response before any following message on the
+// connection is read, even if the response arrives after ctx is done.
+func (wc *WebSocketClient) callWithHook(ctx context.Context, on *websocket.Conn, method string, params interface{}, hook func(*jsonrpc.RPCResponse)) (*jsonrpc.RPCResponse, error) {
+	ctx, cancel := wc.withTimeout(ctx)
+	defer cancel()
+
+	c, ids, err := wc.send(ctx, on, 1, func(nextID func() int) interface{} {
+		id := nextID()
+		if hook != nil {
+			wc.hooks[id] = hook
+		}
+		return &jsonrpc.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      id,
+			Method:  method,
+			Params:  jsonrpc.Params(params),
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wc.receive(ctx, c, ids)
+}
+
+// callBatch implements backend. The requests are sent with new IDs to keep them unique on the connection.
+func (wc *WebSocketClient) callBatch(ctx context.Context, reqs []*jsonrpc.RPCRequest) (jsonrpc.RPCResponses, error) {
+	ctx, cancel := wc.withTimeout(ctx)
+	defer cancel()
+
+	c, ids, err := wc.send(ctx, nil, len(reqs), func(nextID func() int) interface{} {
+		batch := make([]*jsonrpc.RPCRequest, 0, len(reqs))
+		for _, req := range reqs {
+			batch = append(batch, &jsonrpc.RPCRequest{
+				JSONRPC: "2.0",
+				ID:      nextID(),
+				Method:  req.Method,
+				Params:  req.Params,
+			})
+		}
+		return batch
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Like CallBatch over HTTP, the responses are returned with the index of their request as ID
+	originalIDs := make(map[int]int, len(ids))
+	for i, id := range ids {
+		originalIDs[id] = i
+	}
+	rpcResps := make(jsonrpc.RPCResponses, 0, len(ids))
+	for range ids {
+		rpcResp, err := wc.receive(ctx, c, ids)
+		if err != nil {
+			return nil, err
+		}
+		rpcResp.ID = originalIDs[rpcResp.ID]
+		rpcResps = append(rpcResps, rpcResp)
+	}
+
+	return rpcResps, nil
+}
+
+// Subscription delivers the notifications of a subscription on a WebSocketClient
+type Subscription struct {
+	wc     *WebSocketClient
+	method string
+	params interface{}
+	id     string          // subscription ID assigned by the node, renewed on every reconnect
+	rawID  json.RawMessage // subscription ID as sent by the node
+	conn   *websocket.Conn // connection on which the node assigned the subscription ID
+
+	c     chan json.RawMessage
+	mu    sync.Mutex // held while a notification is delivered on c
+	once  sync.Once
+	ended chan struct{}
+	err   error
+}
+
+// Subscribe calls the given subscription method, for example "subscribeForHeadBlock", and returns a
+// Subscription that delivers the results of the notifications for the subscription. The node must
+// respond to the call with the ID of the subscription.
+//
+// Unlike other calls, the subscription method is not retried, since the node may have accepted a
+// call whose response was lost. If the response arrives after ctx is done, the subscription is
+// cancelled at the node.
+//
+// Notifications are buffered, but must be received promptly: a subscription whose buffer is full
+// ends with ErrSubscriptionOverflow. Notifications sent by the node while the client is
+// reconnecting are lost.
+func (wc *WebSocketClient) Subscribe(ctx context.Context, method string, params interface{}) (*Subscription, error) {
+	sub := &Subscription{
+		wc:     wc,
+		method: method,
+		params: params,
+		c:      make(chan json.RawMessage, notificationBuffer),
+		ended:  make(chan struct{}),
+	}
+
+	err := wc.subscribe(ctx, sub)
+	if err != nil {
+		sub.end(err)
+		return nil, err
+	}
+
+	return sub, nil
+}
+
+// subscribe calls the subscription method of sub and registers sub under the returned ID. The
+// subscription is registered before the next message is read, so no notification is missed.
+// If sub ended before the response arrived, the subscription is cancelled at the node instead.
+func (wc *WebSocketClient) subscribe(ctx context.Context, sub *Subscription) error {
+	register := func(rpcResp *jsonrpc.RPCResponse) {
+		var id json.RawMessage
+		if decodeResult(sub.method, rpcResp, &id) != nil {
+			return
+		}
+
+		wc.mu.Lock()
+		defer wc.mu.Unlock()
+
+		// The hook runs while the connection is read, so the connection is the current one
+		conn := wc.conn
+		select {
+		case <-sub.ended:
+			go wc.unsubscribe(context.Background(), conn, id)
+			return
+		default:
+		}
+		delete(wc.subs, sub.key())
+		sub.id, sub.rawID, sub.conn = subscriptionID(id), id, conn
+		wc.subs[sub.key()] = sub
+	}
+
+	rpcResp, err := wc.callWithHook(ctx, nil, sub.method, sub.params, register)
+	if err != nil {
+		return err
+	}
+
+	return decodeResult(sub.method, rpcResp, nil)
+}
+
+// unsubscribe cancels the subscription with the given ID at the node. Subscriptions end with the
+// connection on which they were made, so nothing is sent if conn is no longer connected.
+func (wc *WebSocketClient) unsubscribe(ctx context.Context, conn *websocket.Conn, id json.RawMessage) error {
+	rpcResp, err := wc.callWithHook(ctx, conn, unsubscribeMethod, []interface{}{id}, nil)
+	if errors.Is(err, ErrNotConnected) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return decodeResult(unsubscribeMethod, rpcResp, nil)
+}
+
+// Notifications returns the channel on which the results of the notifications are delivered.
+// The channel is closed when the subscription ends.
+func (s *Subscription) Notifications() <-chan json.RawMessage {
+	return s.c
+}
+
+// Done returns a channel that is closed when the subscription ends
+func (s *Subscription) Done() <-chan struct{} {
+	return s.ended
+}
+
+// Unsubscribe cancels the subscription at the node with the unsubscribe method and ends it.
+// The subscription ends even if the node returns an error. Notifications for the subscription
+// that are still sent by the node are ignored.
+func (s *Subscription) Unsubscribe() error {
+	return s.UnsubscribeContext(context.Background())
+}
+
+// UnsubscribeContext is like Unsubscribe but uses ctx for cancellation and deadlines.
+func (s *Subscription) UnsubscribeContext(ctx context.Context) error {
+	return s.cancel(ctx, nil)
+}
+
+// cancel removes the subscription from the active subscriptions, cancels it at the node and
+// ends it with err
+func (s *Subscription) cancel(ctx context.Context, err error) error {
+	s.wc.mu.Lock()
+	active := s.wc.subs[s.key()] == s
+	if active {
+		delete(s.wc.subs, s.key())
+	}
+	conn, id := s.conn, s.rawID
+	s.wc.mu.Unlock()
+
+	var unsubscribeErr error
+	if active {
+		unsubscribeErr = s.wc.unsubscribe(ctx, conn, id)
+	}
+	s.end(err)
+
+	return unsubscribeErr
+}
+
+// Err returns the error that ended the subscription, or nil if the subscription is active,
+// was unsubscribed or the client was closed.
+func (s *Subscription) Err() error {
+	select {
+	case <-s.ended:
+		return s.err
+	default:
+		return nil
+	}
+}
+
+// key returns the key of the subscription in the active subscriptions. wc.mu must be held.
+func (s *Subscription) key() string {
+	return s.method + "\x00" + s.id
+}
+
+// end ends the subscription with the given error
+func (s *Subscription) end(err error) {
+	s.once.Do(func() {
+		s.err = err
+		close(s.ended)
+
+		// Wait for a notification that is being delivered, before closing the channel
+		s.mu.Lock()
+		close(s.c)
+		s.mu.Unlock()
+	})
+}
+
+// subscriptionID returns the subscription ID as a string. Nodes use either numbers or strings.
+func subscriptionID(raw json.RawMessage) string {
+	var id string
+	if json.Unmarshal(raw, &id) == nil {
+		return id
+	}
+	return string(bytes.TrimSpace(raw))
+}
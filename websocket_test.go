@@ -0,0 +1,377 @@
+// Copyright 2019 Jake "redmaner" van der Putten.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nimiqrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsServer is a test WebSocket server that answers blockNumber, subscribe and unsubscribe calls,
+// and pushes notifications to the subscriptions of the current connection
+type wsServer struct {
+	*httptest.Server
+
+	mu             sync.Mutex
+	conn           *websocket.Conn
+	subscribes     int           // number of subscribe calls
+	subscribeDelay time.Duration // delay before a subscribe call is answered
+	subscribed     chan string
+	unsubscribed   chan string   // params of the unsubscribe calls
+	hold           chan struct{} // if set, the next handshake waits until it is closed
+	holding        chan struct{} // receives when a handshake waits for hold
+}
+
+func newWSServer() *wsServer {
+	ws := &wsServer{
+		subscribed:   make(chan string, 10),
+		unsubscribed: make(chan string, 10),
+		holding:      make(chan struct{}, 1),
+	}
+	upgrader := websocket.Upgrader{}
+	ws.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ws.mu.Lock()
+		hold := ws.hold
+		ws.hold = nil
+		ws.mu.Unlock()
+		if hold != nil {
+			ws.holding <- struct{}{}
+			<-hold
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		ws.mu.Lock()
+		ws.conn = conn
+		ws.mu.Unlock()
+
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(string(data), "[") {
+				var reqs []map[string]interface{}
+				json.Unmarshal(data, &reqs)
+				resps := make([]interface{}, 0, len(reqs))
+				for _, req := range reqs {
+					resps = append(resps, ws.handle(req))
+				}
+				ws.write(resps)
+				continue
+			}
+			var req map[string]interface{}
+			json.Unmarshal(data, &req)
+			if req["method"] == "subscribeForHeadBlockHash" {
+				ws.mu.Lock()
+				delay := ws.subscribeDelay
+				ws.mu.Unlock()
+				time.Sleep(delay)
+			}
+			ws.write(ws.handle(req))
+			switch req["method"] {
+			case "subscribeForHeadBlockHash":
+				ws.subscribed <- "subscribeForHeadBlockHash"
+			case "unsubscribe":
+				params, _ := json.Marshal(req["params"])
+				ws.unsubscribed <- string(params)
+			}
+		}
+	}))
+	return ws
+}
+
+func (ws *wsServer) handle(req map[string]interface{}) interface{} {
+	resp := map[string]interface{}{"jsonrpc": "2.0", "id": req["id"]}
+	switch req["method"] {
+	case "blockNumber":
+		resp["result"] = 42
+	case "consensus":
+		resp["result"] = "established"
+	case "subscribeForHeadBlockHash":
+		ws.mu.Lock()
+		ws.subscribes++
+		id := ws.subscribes
+		ws.mu.Unlock()
+		resp["result"] = id
+	case "unsubscribe":
+		resp["result"] = true
+	default:
+		resp["error"] = map[string]interface{}{"code": -32601, "message": "Method not found"}
+	}
+	return resp
+}
+
+func (ws *wsServer) write(v interface{}) {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.conn.WriteJSON(v)
+}
+
+// notify pushes a notification to the subscription with the latest ID
+func (ws *wsServer) notify(result string) {
+	ws.mu.Lock()
+	id := ws.subscribes
+	ws.mu.Unlock()
+	ws.write(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"method":  "subscribeForHeadBlockHash",
+		"params":  map[string]interface{}{"subscription": id, "result": result},
+	})
+}
+
+// drop closes the current connection
+func (ws *wsServer) drop() {
+	ws.mu.Lock()
+	defer ws.mu.Unlock()
+	ws.conn.Close()
+}
+
+func (ws *wsServer) wsURL() string {
+	return "ws" + strings.TrimPrefix(ws.URL, "http")
+}
+
+func TestWebSocketCall(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wc.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n, err := wc.BlockNumber(); err != nil || n != 42 {
+				t.Errorf("BlockNumber: got %d, %v", n, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	batch := wc.NewBatch()
+	number := batch.BlockNumber()
+	consensus := batch.Consensus()
+	if err := batch.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := number.Result(); err != nil || n != 42 {
+		t.Errorf("batch BlockNumber: got %d, %v", n, err)
+	}
+	if state, err := consensus.Result(); err != nil || state != "established" {
+		t.Errorf("batch Consensus: got %q, %v", state, err)
+	}
+
+	var rpcErr *RPCError
+	if _, err := wc.Hashrate(); !errors.As(err, &rpcErr) || rpcErr.Code != -32601 {
+		t.Errorf("expected *RPCError, got %v", err)
+	}
+}
+
+func TestWebSocketSubscription(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wc.Close()
+
+	sub, err := wc.Subscribe(context.Background(), "subscribeForHeadBlockHash", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-srv.subscribed
+
+	expect := func(hash string) {
+		select {
+		case result := <-sub.Notifications():
+			if string(result) != `"`+hash+`"` {
+				t.Errorf("expected notification %s, got %s", hash, result)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no notification for %s", hash)
+		}
+	}
+
+	srv.notify("hash1")
+	expect("hash1")
+
+	// The client reconnects and renews the subscription
+	srv.drop()
+	select {
+	case <-srv.subscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription was not renewed")
+	}
+	srv.notify("hash2")
+	expect("hash2")
+
+	if n, err := wc.BlockNumber(); err != nil || n != 42 {
+		t.Errorf("BlockNumber after reconnect: got %d, %v", n, err)
+	}
+
+	if err := sub.Unsubscribe(); err != nil {
+		t.Errorf("Unsubscribe: %v", err)
+	}
+	if params := <-srv.unsubscribed; params != "[2]" {
+		t.Errorf("expected unsubscribe of the renewed subscription, got params %s", params)
+	}
+	if _, ok := <-sub.Notifications(); ok {
+		t.Error("expected notifications to be closed after Unsubscribe")
+	}
+}
+
+func TestWebSocketSubscriptionOverflow(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wc.Close()
+
+	sub, err := wc.Subscribe(context.Background(), "subscribeForHeadBlockHash", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-srv.subscribed
+
+	// The notifications are not received, which must not block the connection
+	for i := 0; i <= notificationBuffer; i++ {
+		srv.notify("hash")
+	}
+	if n, err := wc.BlockNumber(); err != nil || n != 42 {
+		t.Errorf("BlockNumber: got %d, %v", n, err)
+	}
+
+	select {
+	case params := <-srv.unsubscribed:
+		if params != "[1]" {
+			t.Errorf("unexpected unsubscribe params %s", params)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("overflowed subscription was not cancelled")
+	}
+	<-sub.Done()
+	if err := sub.Err(); err != ErrSubscriptionOverflow {
+		t.Errorf("expected %v, got %v", ErrSubscriptionOverflow, err)
+	}
+}
+
+func TestWebSocketSubscribeTimeout(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+	srv.subscribeDelay = 200 * time.Millisecond
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wc.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := wc.Subscribe(ctx, "subscribeForHeadBlockHash", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected %v, got %v", context.DeadlineExceeded, err)
+	}
+
+	// The subscription is not retried, and the node cancels the subscription that was accepted late
+	select {
+	case params := <-srv.unsubscribed:
+		if params != "[1]" {
+			t.Errorf("unexpected unsubscribe params %s", params)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("orphaned subscription was not cancelled")
+	}
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.subscribes != 1 {
+		t.Errorf("expected 1 subscribe call, got %d", srv.subscribes)
+	}
+}
+
+func TestWebSocketClose(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sub, err := wc.Subscribe(context.Background(), "subscribeForHeadBlockHash", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wc.Close()
+	if _, ok := <-sub.Notifications(); ok || sub.Err() != nil {
+		t.Errorf("expected subscription to end without error, got %v", sub.Err())
+	}
+	if _, err := wc.BlockNumber(); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected %v, got %v", ErrClosed, err)
+	}
+}
+
+func TestWebSocketCloseWhileReconnecting(t *testing.T) {
+	srv := newWSServer()
+	defer srv.Close()
+
+	wc, err := DialWebSocket(context.Background(), srv.wsURL())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The handshake of the reconnect completes only after Close was called
+	hold := make(chan struct{})
+	srv.mu.Lock()
+	srv.hold = hold
+	srv.mu.Unlock()
+	srv.drop()
+	<-srv.holding
+
+	closed := make(chan struct{})
+	go func() {
+		wc.Close()
+		close(closed)
+	}()
+	<-wc.closed
+	close(hold)
+
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Close did not return while reconnecting")
+	}
+}